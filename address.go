@@ -104,6 +104,30 @@ func (m *AddressMessage) UnmarshalBinary(b []byte) error {
 // rtMessage is an empty method to sattisfy the Message interface.
 func (*AddressMessage) rtMessage() {}
 
+// Validate verifies that the AddressMessage has a supported address family,
+// a prefix length within bounds and addresses matching that family.
+func (m *AddressMessage) Validate() error {
+	if m.Family != unix.AF_INET && m.Family != unix.AF_INET6 {
+		return fmt.Errorf("rtnetlink: unsupported address family %d", m.Family)
+	}
+
+	if err := validatePrefix(m.Family, m.PrefixLength); err != nil {
+		return err
+	}
+
+	if m.Attributes == nil {
+		return nil
+	}
+
+	for _, ip := range []net.IP{m.Attributes.Address, m.Attributes.Local, m.Attributes.Broadcast} {
+		if err := validateIP(m.Family, ip); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // AddressService is used to retrieve rtnetlink family information.
 type AddressService struct {
 	c *Conn
@@ -111,6 +135,10 @@ type AddressService struct {
 
 // New creates a new address using the AddressMessage information.
 func (a *AddressService) New(req *AddressMessage) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
 	flags := netlink.Request | netlink.Create | netlink.Acknowledge | netlink.Excl
 	_, err := a.c.Execute(req, unix.RTM_NEWADDR, flags)
 	if err != nil {
@@ -120,6 +148,55 @@ func (a *AddressService) New(req *AddressMessage) error {
 	return nil
 }
 
+// Replace creates a new address or replaces an existing one using the
+// AddressMessage information.
+func (a *AddressService) Replace(req *AddressMessage) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	flags := netlink.Request | netlink.Create | netlink.Replace | netlink.Acknowledge
+	_, err := a.c.Execute(req, unix.RTM_NEWADDR, flags)
+
+	return err
+}
+
+// AddBatch creates all addresses in reqs. The requests are sent to the
+// kernel at once and every request is attempted, even if an earlier one
+// fails. When one or more requests fail, a *BatchError is returned holding
+// the error for each request.
+func (a *AddressService) AddBatch(reqs []*AddressMessage) error {
+	var (
+		errs   = make([]error, len(reqs))
+		msgs   = make([]Message, 0, len(reqs))
+		idx    = make([]int, 0, len(reqs))
+		failed bool
+	)
+	for i, req := range reqs {
+		if err := req.Validate(); err != nil {
+			errs[i] = err
+			failed = true
+			continue
+		}
+		msgs = append(msgs, req)
+		idx = append(idx, i)
+	}
+
+	flags := netlink.Request | netlink.Create | netlink.Acknowledge | netlink.Excl
+	for i, err := range a.c.executeBatch(msgs, unix.RTM_NEWADDR, flags) {
+		if err != nil {
+			errs[idx[i]] = err
+			failed = true
+		}
+	}
+
+	if failed {
+		return &BatchError{Errors: errs}
+	}
+
+	return nil
+}
+
 // Delete removes an address using the AddressMessage information.
 func (a *AddressService) Delete(req *AddressMessage) error {
 	flags := netlink.Request | netlink.Acknowledge
@@ -131,6 +208,56 @@ func (a *AddressService) Delete(req *AddressMessage) error {
 	return nil
 }
 
+// Flush removes all addresses of the given family from the interface with
+// the given index, like `ip address flush`. A family of 0 (AF_UNSPEC)
+// removes addresses of all families. IPv6 link-local addresses are kept, as
+// the kernel manages them for the interface.
+func (a *AddressService) Flush(index uint32, family uint8) error {
+	addrs, err := a.List()
+	if err != nil {
+		return err
+	}
+
+	var primary, secondary []AddressMessage
+	for _, m := range addrs {
+		if m.Index != index || (family != unix.AF_UNSPEC && m.Family != family) {
+			continue
+		}
+		if m.Attributes == nil {
+			continue
+		}
+		if m.Family == unix.AF_INET6 && m.Attributes.Address.IsLinkLocalUnicast() {
+			continue
+		}
+
+		// Removing a primary IPv4 address also removes its secondary
+		// addresses, unless they are promoted, so they go first.
+		if m.Flags&unix.IFA_F_SECONDARY != 0 || m.Attributes.Flags&unix.IFA_F_SECONDARY != 0 {
+			secondary = append(secondary, m)
+		} else {
+			primary = append(primary, m)
+		}
+	}
+
+	for _, m := range append(secondary, primary...) {
+		req := &AddressMessage{
+			Family:       m.Family,
+			PrefixLength: m.PrefixLength,
+			Scope:        m.Scope,
+			Index:        m.Index,
+			Attributes: &AddressAttributes{
+				Address: m.Attributes.Address,
+				Local:   m.Attributes.Local,
+			},
+		}
+		if err := a.Delete(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // List retrieves all addresses.
 func (a *AddressService) List() ([]AddressMessage, error) {
 	req := AddressMessage{}
@@ -148,6 +275,25 @@ func (a *AddressService) List() ([]AddressMessage, error) {
 	return addresses, nil
 }
 
+// Get retrieves the addresses of the interface with the given index. The
+// kernel does not filter address dumps by interface, so all addresses are
+// dumped and filtered here.
+func (a *AddressService) Get(index uint32) ([]AddressMessage, error) {
+	addrs, err := a.List()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := addrs[:0]
+	for _, m := range addrs {
+		if m.Index == index {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return filtered, nil
+}
+
 // AddressAttributes contains all attributes for an interface.
 type AddressAttributes struct {
 	Address   net.IP // Interface Ip address
@@ -157,9 +303,19 @@ type AddressAttributes struct {
 	Anycast   net.IP    // Anycast Ip address
 	CacheInfo CacheInfo // Address information
 	Multicast net.IP    // Multicast Ip address
-	Flags     uint32    // Address flags
+	Flags     uint32    // Address flags, including the extended IFA_F_* flags
+
+	// ValidLifetime and PreferredLifetime are the lifetimes in seconds of an
+	// address to add, encoded as IFA_CACHEINFO when either is set. An unset
+	// lifetime defaults to InfiniteLifetime. The lifetimes reported by the
+	// kernel are held by CacheInfo (write only).
+	ValidLifetime     *uint32
+	PreferredLifetime *uint32
 }
 
+// InfiniteLifetime is the lifetime of an address that does not expire.
+const InfiniteLifetime uint32 = 0xffffffff
+
 func (a *AddressAttributes) decode(ad *netlink.AttributeDecoder) error {
 	for ad.Next() {
 		switch ad.Type() {
@@ -206,10 +362,30 @@ func (a *AddressAttributes) encode(ae *netlink.AttributeEncoder) error {
 		ae.String(unix.IFA_LABEL, a.Label)
 	}
 	ae.Uint32(unix.IFA_FLAGS, a.Flags)
+	if a.ValidLifetime != nil || a.PreferredLifetime != nil {
+		ae.Bytes(unix.IFA_CACHEINFO, a.encodeLifetimes())
+	}
 
 	return nil
 }
 
+// encodeLifetimes encodes the lifetimes of an address in the layout of
+// struct ifa_cacheinfo. The timestamps are set by the kernel.
+func (a *AddressAttributes) encodeLifetimes() []byte {
+	preferred, valid := InfiniteLifetime, InfiniteLifetime
+	if a.PreferredLifetime != nil {
+		preferred = *a.PreferredLifetime
+	}
+	if a.ValidLifetime != nil {
+		valid = *a.ValidLifetime
+	}
+
+	b := make([]byte, 16)
+	nativeEndian.PutUint32(b[0:4], preferred)
+	nativeEndian.PutUint32(b[4:8], valid)
+	return b
+}
+
 // CacheInfo contains address information
 type CacheInfo struct {
 	Preferred uint32
@@ -267,3 +443,47 @@ func decodeIP(ip *net.IP) func(b []byte) error {
 		return nil
 	}
 }
+
+// validatePrefix verifies that length is a valid prefix length for the IPv4
+// or IPv6 address family. Other families are not checked.
+func validatePrefix(family uint8, length uint8) error {
+	var bits uint8
+	switch family {
+	case unix.AF_INET:
+		bits = 8 * net.IPv4len
+	case unix.AF_INET6:
+		bits = 8 * net.IPv6len
+	default:
+		return nil
+	}
+
+	if length > bits {
+		return fmt.Errorf("rtnetlink: prefix length %d exceeds %d bits for family %d", length, bits, family)
+	}
+
+	return nil
+}
+
+// validateIP verifies that ip will be encoded with the length expected for
+// the IPv4 or IPv6 address family. Nil addresses and other families are not
+// checked. IPv4-mapped IPv6 addresses are valid for AF_INET6, as net.IP
+// cannot tell them apart from IPv4 addresses in their 16 byte form, only
+// 4 byte IPv4 addresses are rejected.
+func validateIP(family uint8, ip net.IP) error {
+	if ip == nil {
+		return nil
+	}
+
+	switch family {
+	case unix.AF_INET:
+		if ip.To4() == nil {
+			return fmt.Errorf("rtnetlink: address %s is not valid for family AF_INET", ip)
+		}
+	case unix.AF_INET6:
+		if ip.To16() == nil || len(ip) == net.IPv4len {
+			return fmt.Errorf("rtnetlink: address %s is not valid for family AF_INET6", ip)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+//go:build integration
+// +build integration
+
+package rtnetlink
+
+import (
+	"net"
+	"testing"
+
+	"github.com/jsimonetti/rtnetlink/v2/internal/testutils"
+	"github.com/mdlayher/netlink"
+	"golang.org/x/sys/unix"
+)
+
+func TestAddressFlush(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	for _, a := range []struct {
+		family uint8
+		ip     net.IP
+		prefix uint8
+	}{
+		{unix.AF_INET, net.IPv4(192, 0, 2, 1).To4(), 24},
+		{unix.AF_INET, net.IPv4(192, 0, 2, 2).To4(), 24},
+		{unix.AF_INET, net.IPv4(198, 51, 100, 1).To4(), 24},
+		{unix.AF_INET6, net.ParseIP("2001:db8::1"), 64},
+		{unix.AF_INET6, net.ParseIP("fe80::1"), 64},
+	} {
+		if err := conn.Address.New(&AddressMessage{
+			Family:       a.family,
+			PrefixLength: a.prefix,
+			Index:        lo,
+			Attributes: &AddressAttributes{
+				Address: a.ip,
+				Local:   a.ip,
+			},
+		}); err != nil {
+			t.Fatalf("failed to add address %s: %v", a.ip, err)
+		}
+	}
+
+	count := func(family uint8) int {
+		t.Helper()
+
+		addrs, err := conn.Address.List()
+		if err != nil {
+			t.Fatalf("failed to list addresses: %v", err)
+		}
+
+		var n int
+		for _, a := range addrs {
+			if a.Index == lo && a.Family == family {
+				n++
+			}
+		}
+		return n
+	}
+
+	if err := conn.Address.Flush(lo, unix.AF_INET); err != nil {
+		t.Fatalf("failed to flush IPv4 addresses: %v", err)
+	}
+	if n := count(unix.AF_INET); n != 0 {
+		t.Fatalf("expected no IPv4 addresses after flush, got %d", n)
+	}
+	// The loopback interface of a new namespace is down and has no ::1,
+	// so 2001:db8::1 and fe80::1 remain.
+	if n := count(unix.AF_INET6); n != 2 {
+		t.Fatalf("expected IPv6 addresses to be untouched, got %d", n)
+	}
+
+	if err := conn.Address.Flush(lo, unix.AF_UNSPEC); err != nil {
+		t.Fatalf("failed to flush all addresses: %v", err)
+	}
+	// Only the link-local address remains.
+	if n := count(unix.AF_INET6); n != 1 {
+		t.Fatalf("expected only the link-local address after flush, got %d", n)
+	}
+}
+
+func TestAddressLifetimes(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	var (
+		ip                      = net.IPv4(192, 0, 2, 1).To4()
+		valid, preferred uint32 = 3600, 1800
+	)
+	if err := conn.Address.New(&AddressMessage{
+		Family:       unix.AF_INET,
+		PrefixLength: 24,
+		Index:        lo,
+		Attributes: &AddressAttributes{
+			Address:           ip,
+			Local:             ip,
+			ValidLifetime:     &valid,
+			PreferredLifetime: &preferred,
+		},
+	}); err != nil {
+		t.Fatalf("failed to add address: %v", err)
+	}
+
+	addrs, err := conn.Address.List()
+	if err != nil {
+		t.Fatalf("failed to list addresses: %v", err)
+	}
+
+	for _, a := range addrs {
+		if a.Index != lo || !a.Attributes.Address.Equal(ip) {
+			continue
+		}
+
+		// The kernel reports the remaining lifetimes.
+		ci := a.Attributes.CacheInfo
+		if ci.Valid == 0 || ci.Valid > valid || ci.Preferred == 0 || ci.Preferred > preferred {
+			t.Fatalf("unexpected lifetimes, valid: %d, preferred: %d", ci.Valid, ci.Preferred)
+		}
+		return
+	}
+
+	t.Fatalf("address %s not found", ip)
+}
@@ -75,6 +75,58 @@ func TestAddressMessageMarshalBinary(t *testing.T) {
 				0x00, 0x00, 0x00, 0x00,
 			},
 		},
+		{
+			name: "lifetimes and extended flags",
+			m: &AddressMessage{
+				Family:       unix.AF_INET6,
+				PrefixLength: 64,
+				Index:        2,
+				Attributes: &AddressAttributes{
+					Address:           net.ParseIP("2001:db8::1"),
+					Flags:             unix.IFA_F_NODAD | unix.IFA_F_MANAGETEMPADDR,
+					ValidLifetime:     uint32Ptr(3600),
+					PreferredLifetime: uint32Ptr(1800),
+				},
+			},
+			b: []byte{
+				0x0a, 0x40, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+				// IFA_UNSPEC
+				0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				// IFA_ADDRESS
+				0x14, 0x00, 0x01, 0x00, 0x20, 0x01, 0x0d, 0xb8,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x01,
+				// IFA_FLAGS: IFA_F_NODAD | IFA_F_MANAGETEMPADDR
+				0x08, 0x00, 0x08, 0x00, 0x02, 0x01, 0x00, 0x00,
+				// IFA_CACHEINFO
+				0x14, 0x00, 0x06, 0x00,
+				0x08, 0x07, 0x00, 0x00, // preferred
+				0x10, 0x0e, 0x00, 0x00, // valid
+				0x00, 0x00, 0x00, 0x00, // created
+				0x00, 0x00, 0x00, 0x00, // updated
+			},
+		},
+		{
+			name: "valid lifetime only",
+			m: &AddressMessage{
+				Attributes: &AddressAttributes{
+					Address:       net.IPv4(192, 0, 2, 1),
+					ValidLifetime: uint32Ptr(60),
+				},
+			},
+			b: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x08, 0x00, 0x01, 0x00, 0xc0, 0x00, 0x02, 0x01,
+				0x08, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00,
+				// IFA_CACHEINFO
+				0x14, 0x00, 0x06, 0x00,
+				0xff, 0xff, 0xff, 0xff, // preferred
+				0x3c, 0x00, 0x00, 0x00, // valid
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -182,8 +234,119 @@ func TestAddressMessageUnmarshalBinary(t *testing.T) {
 	}
 }
 
+func TestAddressMessageRoundTrip(t *testing.T) {
+	m := &AddressMessage{
+		Family:       unix.AF_INET,
+		PrefixLength: 24,
+		Flags:        0x81, // IFA_F_PERMANENT | IFA_F_SECONDARY
+		Scope:        unix.RT_SCOPE_LINK,
+		Index:        0x01020304,
+	}
+
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	var got AddressMessage
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if diff := cmp.Diff(m, &got); diff != "" {
+		t.Fatalf("unexpected address message (-want +got):\n%s", diff)
+	}
+}
+
 func skipBigEndian(t *testing.T) {
 	if nlenc.NativeEndian() == binary.BigEndian {
 		t.Skip("skipping test on big-endian system")
 	}
 }
+
+func TestAddressMessageValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *AddressMessage
+		ok   bool
+	}{
+		{
+			name: "unspecified family",
+			m:    &AddressMessage{},
+		},
+		{
+			name: "IPv4 prefix too long",
+			m: &AddressMessage{
+				Family:       unix.AF_INET,
+				PrefixLength: 33,
+			},
+		},
+		{
+			name: "IPv6 address with IPv4 family",
+			m: &AddressMessage{
+				Family:       unix.AF_INET,
+				PrefixLength: 24,
+				Attributes: &AddressAttributes{
+					Address: net.ParseIP("2001:db8::1"),
+				},
+			},
+		},
+		{
+			name: "IPv4 address with IPv6 family",
+			m: &AddressMessage{
+				Family:       unix.AF_INET6,
+				PrefixLength: 64,
+				Attributes: &AddressAttributes{
+					Local: net.IPv4(192, 0, 2, 1).To4(),
+				},
+			},
+		},
+		{
+			name: "OK IPv4",
+			m: &AddressMessage{
+				Family:       unix.AF_INET,
+				PrefixLength: 24,
+				Attributes: &AddressAttributes{
+					Address:   net.IPv4(192, 0, 2, 1),
+					Local:     net.IPv4(192, 0, 2, 1),
+					Broadcast: net.IPv4(192, 0, 2, 255),
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "OK IPv6",
+			m: &AddressMessage{
+				Family:       unix.AF_INET6,
+				PrefixLength: 128,
+				Attributes: &AddressAttributes{
+					Address: net.ParseIP("2001:db8::1"),
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "OK IPv4-mapped IPv6",
+			m: &AddressMessage{
+				Family:       unix.AF_INET6,
+				PrefixLength: 128,
+				Attributes: &AddressAttributes{
+					Address: net.ParseIP("::ffff:192.0.2.1"),
+				},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.m.Validate()
+			if tt.ok && err != nil {
+				t.Fatalf("failed to validate message: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
@@ -0,0 +1,401 @@
+package rtnetlink
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+// BridgeVlanService is used to manage the VLANs of bridges and bridge ports,
+// the equivalent of `bridge vlan add` and `bridge vlan del`.
+type BridgeVlanService struct {
+	c *Conn
+}
+
+// BridgeVlanFlags specifies the options of a bridge VLAN entry
+type BridgeVlanFlags uint16
+
+const (
+	// Operate on the bridge device as well when configuring a port
+	BridgeVlanFlagMaster BridgeVlanFlags = unix.BRIDGE_VLAN_INFO_MASTER
+
+	// The VLAN is the PVID, untagged ingress packets are assigned to it
+	BridgeVlanFlagPVID BridgeVlanFlags = unix.BRIDGE_VLAN_INFO_PVID
+
+	// Egress packets of the VLAN are sent untagged
+	BridgeVlanFlagUntagged BridgeVlanFlags = unix.BRIDGE_VLAN_INFO_UNTAGGED
+
+	// The entry is the first VLAN of a range, set by AddRange and DeleteRange
+	BridgeVlanFlagRangeBegin BridgeVlanFlags = unix.BRIDGE_VLAN_INFO_RANGE_BEGIN
+
+	// The entry is the last VLAN of a range, set by AddRange and DeleteRange
+	BridgeVlanFlagRangeEnd BridgeVlanFlags = unix.BRIDGE_VLAN_INFO_RANGE_END
+
+	// The VLAN is a global entry of the bridge device
+	BridgeVlanFlagBrEntry BridgeVlanFlags = unix.BRIDGE_VLAN_INFO_BRENTRY
+)
+
+// BridgeVlanTarget selects which device a bridge VLAN request is handled by.
+type BridgeVlanTarget uint8
+
+const (
+	// BridgeVlanTargetMaster targets the bridge a port is enslaved to, which
+	// configures the VLANs of the port (BRIDGE_FLAGS_MASTER). This is the
+	// kernel default.
+	BridgeVlanTargetMaster BridgeVlanTarget = iota
+
+	// BridgeVlanTargetSelf targets the device itself, which configures the
+	// VLANs of a bridge device (BRIDGE_FLAGS_SELF).
+	BridgeVlanTargetSelf
+)
+
+// BridgeVlanInfo is a bridge VLAN entry, the struct bridge_vlan_info of the
+// kernel.
+type BridgeVlanInfo struct {
+	Flags BridgeVlanFlags
+	VID   uint16
+}
+
+const sizeofBridgeVlanInfo = 4
+
+func (v *BridgeVlanInfo) marshalBinary() []byte {
+	b := make([]byte, sizeofBridgeVlanInfo)
+	nativeEndian.PutUint16(b[0:2], uint16(v.Flags))
+	nativeEndian.PutUint16(b[2:4], v.VID)
+	return b
+}
+
+func (v *BridgeVlanInfo) unmarshalBinary(b []byte) error {
+	if len(b) != sizeofBridgeVlanInfo {
+		return fmt.Errorf("incorrect bridge vlan info size, want: %d, got: %d", sizeofBridgeVlanInfo, len(b))
+	}
+
+	v.Flags = BridgeVlanFlags(nativeEndian.Uint16(b[0:2]))
+	v.VID = nativeEndian.Uint16(b[2:4])
+	return nil
+}
+
+// BridgeVlanTunnelInfo maps a VLAN of a bridge port to a tunnel ID, such as
+// the VNI of a VXLAN device in collect metadata mode. The port must have VLAN
+// tunneling enabled.
+type BridgeVlanTunnelInfo struct {
+	// Only BridgeVlanFlagRangeBegin and BridgeVlanFlagRangeEnd are used
+	Flags    BridgeVlanFlags
+	VID      uint16
+	TunnelID uint32
+}
+
+func (v *BridgeVlanTunnelInfo) encode(ae *netlink.AttributeEncoder) error {
+	ae.Uint32(unix.IFLA_BRIDGE_VLAN_TUNNEL_ID, v.TunnelID)
+	ae.Uint16(unix.IFLA_BRIDGE_VLAN_TUNNEL_VID, v.VID)
+	if v.Flags != 0 {
+		ae.Uint16(unix.IFLA_BRIDGE_VLAN_TUNNEL_FLAGS, uint16(v.Flags))
+	}
+	return nil
+}
+
+func (v *BridgeVlanTunnelInfo) decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_BRIDGE_VLAN_TUNNEL_ID:
+			v.TunnelID = ad.Uint32()
+		case unix.IFLA_BRIDGE_VLAN_TUNNEL_VID:
+			v.VID = ad.Uint16()
+		case unix.IFLA_BRIDGE_VLAN_TUNNEL_FLAGS:
+			v.Flags = BridgeVlanFlags(ad.Uint16())
+		}
+	}
+	return nil
+}
+
+var _ Message = &BridgeVlanMessage{}
+
+// A BridgeVlanMessage is a route netlink link message of the AF_BRIDGE
+// family carrying the VLANs of a bridge or bridge port.
+type BridgeVlanMessage struct {
+	// Interface index of the bridge or bridge port
+	Index uint32
+
+	// Selects the device handling the request, only used in requests
+	Target BridgeVlanTarget
+
+	// The VLAN entries, a range is given by an entry flagged with
+	// BridgeVlanFlagRangeBegin followed by one flagged with
+	// BridgeVlanFlagRangeEnd
+	Vlans []BridgeVlanInfo
+
+	// The VLAN to tunnel ID mappings, a range is given the same way as for
+	// Vlans
+	Tunnels []BridgeVlanTunnelInfo
+}
+
+// MarshalBinary marshals a BridgeVlanMessage into a byte slice.
+func (m *BridgeVlanMessage) MarshalBinary() ([]byte, error) {
+	b := make([]byte, unix.SizeofIfInfomsg)
+	b[0] = unix.AF_BRIDGE
+	nativeEndian.PutUint32(b[4:8], m.Index)
+
+	ae := netlink.NewAttributeEncoder()
+	ae.ByteOrder = nativeEndian
+	ae.Nested(unix.IFLA_AF_SPEC, func(nae *netlink.AttributeEncoder) error {
+		switch m.Target {
+		case BridgeVlanTargetMaster:
+			nae.Uint16(unix.IFLA_BRIDGE_FLAGS, unix.BRIDGE_FLAGS_MASTER)
+		case BridgeVlanTargetSelf:
+			nae.Uint16(unix.IFLA_BRIDGE_FLAGS, unix.BRIDGE_FLAGS_SELF)
+		default:
+			return fmt.Errorf("rtnetlink: unknown BridgeVlanTarget value (%d)", m.Target)
+		}
+		for i := range m.Vlans {
+			nae.Bytes(unix.IFLA_BRIDGE_VLAN_INFO, m.Vlans[i].marshalBinary())
+		}
+		for i := range m.Tunnels {
+			nae.Nested(unix.IFLA_BRIDGE_VLAN_TUNNEL_INFO, m.Tunnels[i].encode)
+		}
+		return nil
+	})
+
+	a, err := ae.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, a...), nil
+}
+
+// UnmarshalBinary unmarshals the contents of a byte slice into a
+// BridgeVlanMessage.
+func (m *BridgeVlanMessage) UnmarshalBinary(b []byte) error {
+	if len(b) < unix.SizeofIfInfomsg {
+		return errInvalidLinkMessage
+	}
+	if b[0] != unix.AF_BRIDGE {
+		return fmt.Errorf("rtnetlink: bridge vlan message requires family AF_BRIDGE, got %d", b[0])
+	}
+
+	m.Index = nativeEndian.Uint32(b[4:8])
+	m.Vlans = nil
+	m.Tunnels = nil
+
+	ad, err := netlink.NewAttributeDecoder(b[unix.SizeofIfInfomsg:])
+	if err != nil {
+		return err
+	}
+	ad.ByteOrder = nativeEndian
+
+	for ad.Next() {
+		if ad.Type() != unix.IFLA_AF_SPEC {
+			continue
+		}
+		ad.Nested(func(nad *netlink.AttributeDecoder) error {
+			for nad.Next() {
+				switch nad.Type() {
+				case unix.IFLA_BRIDGE_VLAN_INFO:
+					var v BridgeVlanInfo
+					if err := v.unmarshalBinary(nad.Bytes()); err != nil {
+						return err
+					}
+					m.Vlans = append(m.Vlans, v)
+				case unix.IFLA_BRIDGE_VLAN_TUNNEL_INFO:
+					var v BridgeVlanTunnelInfo
+					nad.Nested(v.decode)
+					m.Tunnels = append(m.Tunnels, v)
+				}
+			}
+			return nil
+		})
+	}
+
+	return ad.Err()
+}
+
+// rtMessage is an empty method to sattisfy the Message interface.
+func (*BridgeVlanMessage) rtMessage() {}
+
+// errBridgeVlanRange is returned for a VLAN range that is empty or reversed.
+var errBridgeVlanRange = errors.New("rtnetlink: the first VLAN of a range must be lower than the last")
+
+// verifyBridgeVid checks that vid is a usable VLAN identifier.
+func verifyBridgeVid(vid uint16) error {
+	if vid < 1 || vid > 4094 {
+		return fmt.Errorf("rtnetlink: invalid VLAN identifier %d, must be between 1 and 4094", vid)
+	}
+	return nil
+}
+
+// bridgeVlanRange returns the entries of the VLAN range from-to with flags,
+// or a single entry if from equals to.
+func bridgeVlanRange(from, to uint16, flags BridgeVlanFlags) ([]BridgeVlanInfo, error) {
+	for _, vid := range []uint16{from, to} {
+		if err := verifyBridgeVid(vid); err != nil {
+			return nil, err
+		}
+	}
+
+	flags &^= BridgeVlanFlagRangeBegin | BridgeVlanFlagRangeEnd
+	switch {
+	case from == to:
+		return []BridgeVlanInfo{{Flags: flags, VID: from}}, nil
+	case from > to:
+		return nil, errBridgeVlanRange
+	}
+
+	return []BridgeVlanInfo{
+		{Flags: flags | BridgeVlanFlagRangeBegin, VID: from},
+		{Flags: flags | BridgeVlanFlagRangeEnd, VID: to},
+	}, nil
+}
+
+// bridgeVlanTunnelRange returns the entries mapping the VLAN range from-to to
+// the tunnel IDs starting at tunnelID, or a single entry if from equals to.
+func bridgeVlanTunnelRange(from, to uint16, tunnelID uint32) ([]BridgeVlanTunnelInfo, error) {
+	vlans, err := bridgeVlanRange(from, to, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if uint64(tunnelID)+uint64(to-from) > math.MaxUint32 {
+		return nil, fmt.Errorf("rtnetlink: tunnel ID range starting at %d exceeds the maximum tunnel ID", tunnelID)
+	}
+
+	tunnels := make([]BridgeVlanTunnelInfo, 0, len(vlans))
+	for _, v := range vlans {
+		tunnels = append(tunnels, BridgeVlanTunnelInfo{
+			Flags:    v.Flags,
+			VID:      v.VID,
+			TunnelID: tunnelID + uint32(v.VID-from),
+		})
+	}
+	return tunnels, nil
+}
+
+// Add adds the VLAN vid with flags to the bridge port with the given index.
+func (b *BridgeVlanService) Add(index uint32, vid uint16, flags BridgeVlanFlags) error {
+	return b.AddRange(index, vid, vid, flags)
+}
+
+// Delete removes the VLAN vid from the bridge port with the given index.
+func (b *BridgeVlanService) Delete(index uint32, vid uint16) error {
+	return b.DeleteRange(index, vid, vid)
+}
+
+// AddRange adds the VLANs from through to with flags to the bridge port with
+// the given index.
+func (b *BridgeVlanService) AddRange(index uint32, from, to uint16, flags BridgeVlanFlags) error {
+	vlans, err := bridgeVlanRange(from, to, flags)
+	if err != nil {
+		return err
+	}
+
+	return b.AddVlans(&BridgeVlanMessage{Index: index, Vlans: vlans})
+}
+
+// DeleteRange removes the VLANs from through to from the bridge port with the
+// given index.
+func (b *BridgeVlanService) DeleteRange(index uint32, from, to uint16) error {
+	vlans, err := bridgeVlanRange(from, to, 0)
+	if err != nil {
+		return err
+	}
+
+	return b.DeleteVlans(&BridgeVlanMessage{Index: index, Vlans: vlans})
+}
+
+// AddTunnel maps the VLAN vid of the bridge port with the given index to
+// tunnelID. The VLAN must already be configured on the port.
+func (b *BridgeVlanService) AddTunnel(index uint32, vid uint16, tunnelID uint32) error {
+	return b.AddTunnelRange(index, vid, vid, tunnelID)
+}
+
+// DeleteTunnel removes the mapping of the VLAN vid of the bridge port with the
+// given index to tunnelID.
+func (b *BridgeVlanService) DeleteTunnel(index uint32, vid uint16, tunnelID uint32) error {
+	return b.DeleteTunnelRange(index, vid, vid, tunnelID)
+}
+
+// AddTunnelRange maps the VLANs from through to of the bridge port with the
+// given index to the tunnel IDs starting at tunnelID.
+func (b *BridgeVlanService) AddTunnelRange(index uint32, from, to uint16, tunnelID uint32) error {
+	tunnels, err := bridgeVlanTunnelRange(from, to, tunnelID)
+	if err != nil {
+		return err
+	}
+
+	return b.AddVlans(&BridgeVlanMessage{Index: index, Tunnels: tunnels})
+}
+
+// DeleteTunnelRange removes the mappings of the VLANs from through to of the
+// bridge port with the given index to the tunnel IDs starting at tunnelID.
+func (b *BridgeVlanService) DeleteTunnelRange(index uint32, from, to uint16, tunnelID uint32) error {
+	tunnels, err := bridgeVlanTunnelRange(from, to, tunnelID)
+	if err != nil {
+		return err
+	}
+
+	return b.DeleteVlans(&BridgeVlanMessage{Index: index, Tunnels: tunnels})
+}
+
+// AddVlans adds the VLAN entries of req to the device selected by its Index
+// and Target. Use BridgeVlanTargetSelf to configure the VLANs of a bridge
+// device itself.
+func (b *BridgeVlanService) AddVlans(req *BridgeVlanMessage) error {
+	flags := netlink.Request | netlink.Acknowledge
+	_, err := b.c.Execute(req, unix.RTM_SETLINK, flags)
+
+	return err
+}
+
+// DeleteVlans removes the VLAN entries of req from the device selected by its
+// Index and Target.
+func (b *BridgeVlanService) DeleteVlans(req *BridgeVlanMessage) error {
+	flags := netlink.Request | netlink.Acknowledge
+	_, err := b.c.Execute(req, unix.RTM_DELLINK, flags)
+
+	return err
+}
+
+// List retrieves the VLANs of all bridges and bridge ports. Each VLAN is
+// reported as a separate entry.
+func (b *BridgeVlanService) List() ([]BridgeVlanMessage, error) {
+	hdr := make([]byte, unix.SizeofIfInfomsg)
+	hdr[0] = unix.AF_BRIDGE
+
+	ae := netlink.NewAttributeEncoder()
+	ae.ByteOrder = nativeEndian
+	ae.Uint32(unix.IFLA_EXT_MASK, unix.RTEXT_FILTER_BRVLAN)
+	attrs, err := ae.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	// The replies are link messages of the AF_BRIDGE family, which
+	// unpackMessages would decode as LinkMessages, so they are decoded here.
+	msgs, err := b.c.execute(netlink.Message{
+		Header: netlink.Header{
+			Type:  unix.RTM_GETLINK,
+			Flags: netlink.Request | netlink.Dump,
+		},
+		Data: append(hdr, attrs...),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vlans := make([]BridgeVlanMessage, 0, len(msgs))
+	for _, nm := range msgs {
+		if nm.Header.Type != unix.RTM_NEWLINK {
+			continue
+		}
+
+		var m BridgeVlanMessage
+		if err := m.UnmarshalBinary(nm.Data); err != nil {
+			return nil, err
+		}
+		vlans = append(vlans, m)
+	}
+
+	return vlans, nil
+}
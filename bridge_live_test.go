@@ -0,0 +1,223 @@
+//go:build integration
+// +build integration
+
+package rtnetlink
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2/internal/testutils"
+	"github.com/mdlayher/netlink"
+	"golang.org/x/sys/unix"
+)
+
+func TestBridgeVlan(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	const (
+		bridgeIndex = 1800
+		vethIndex   = 1801
+	)
+
+	if err := conn.Link.New(&LinkMessage{
+		Index: bridgeIndex,
+		Attributes: &LinkAttributes{
+			Info: &LinkInfo{
+				Kind: "bridge",
+				Data: &LinkData{Name: "bridge"},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("failed to create bridge: %v", err)
+	}
+	defer conn.Link.Delete(bridgeIndex)
+
+	createVeth(t, conn, vethIndex, vethIndex+1)
+	defer conn.Link.Delete(vethIndex)
+
+	if err := conn.Link.Enslave(vethIndex, bridgeIndex, nil); err != nil {
+		t.Fatalf("failed to enslave veth: %v", err)
+	}
+
+	// vlans returns the VLANs of index other than the default VLAN 1.
+	vlans := func(index uint32) map[uint16]BridgeVlanFlags {
+		t.Helper()
+
+		msgs, err := conn.BridgeVlan.List()
+		if err != nil {
+			t.Fatalf("failed to list bridge VLANs: %v", err)
+		}
+
+		got := make(map[uint16]BridgeVlanFlags)
+		for _, m := range msgs {
+			if m.Index != index {
+				continue
+			}
+			for _, v := range m.Vlans {
+				if v.VID != 1 {
+					got[v.VID] = v.Flags
+				}
+			}
+		}
+		return got
+	}
+
+	// Configure the port through its bridge (master).
+	if err := conn.BridgeVlan.Add(vethIndex, 10, BridgeVlanFlagPVID|BridgeVlanFlagUntagged); err != nil {
+		t.Fatalf("failed to add VLAN to port: %v", err)
+	}
+	if err := conn.BridgeVlan.AddRange(vethIndex, 20, 22, 0); err != nil {
+		t.Fatalf("failed to add VLAN range to port: %v", err)
+	}
+
+	// Configure the bridge device itself (self).
+	if err := conn.BridgeVlan.AddVlans(&BridgeVlanMessage{
+		Index:  bridgeIndex,
+		Target: BridgeVlanTargetSelf,
+		Vlans:  []BridgeVlanInfo{{VID: 30}},
+	}); err != nil {
+		t.Fatalf("failed to add VLAN to bridge: %v", err)
+	}
+
+	want := map[uint16]BridgeVlanFlags{
+		10: BridgeVlanFlagPVID | BridgeVlanFlagUntagged,
+		20: 0,
+		21: 0,
+		22: 0,
+	}
+	if diff := cmp.Diff(want, vlans(vethIndex)); diff != "" {
+		t.Fatalf("unexpected port VLANs (-want +got):\n%s", diff)
+	}
+	if _, ok := vlans(bridgeIndex)[30]; !ok {
+		t.Fatal("VLAN 30 was not added to the bridge")
+	}
+	if _, ok := vlans(vethIndex)[30]; ok {
+		t.Fatal("VLAN 30 was unexpectedly added to the port")
+	}
+
+	if err := conn.BridgeVlan.Delete(vethIndex, 10); err != nil {
+		t.Fatalf("failed to delete VLAN from port: %v", err)
+	}
+	if err := conn.BridgeVlan.DeleteRange(vethIndex, 20, 21); err != nil {
+		t.Fatalf("failed to delete VLAN range from port: %v", err)
+	}
+
+	want = map[uint16]BridgeVlanFlags{22: 0}
+	if diff := cmp.Diff(want, vlans(vethIndex)); diff != "" {
+		t.Fatalf("unexpected port VLANs after delete (-want +got):\n%s", diff)
+	}
+}
+
+func TestBridgeVlanTunnel(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	const (
+		bridgeIndex = 1810
+		vxlanIndex  = 1811
+	)
+
+	encode := func(fn func(ae *netlink.AttributeEncoder)) []byte {
+		t.Helper()
+
+		ae := netlink.NewAttributeEncoder()
+		fn(ae)
+		b, err := ae.Encode()
+		if err != nil {
+			t.Fatalf("failed to encode attributes: %v", err)
+		}
+		return b
+	}
+
+	for _, l := range []struct {
+		index uint32
+		name  string
+		data  []byte
+	}{
+		{bridgeIndex, "bridge", encode(func(ae *netlink.AttributeEncoder) {
+			ae.Uint8(unix.IFLA_BR_VLAN_FILTERING, 1)
+		})},
+		{vxlanIndex, "vxlan", encode(func(ae *netlink.AttributeEncoder) {
+			ae.Flag(unix.IFLA_VXLAN_COLLECT_METADATA, true)
+		})},
+	} {
+		if err := conn.Link.New(&LinkMessage{
+			Index: l.index,
+			Attributes: &LinkAttributes{
+				Info: &LinkInfo{
+					Kind: l.name,
+					Data: &LinkData{Name: l.name, Data: l.data},
+				},
+			},
+		}); err != nil {
+			t.Fatalf("failed to create %s link: %v", l.name, err)
+		}
+		defer conn.Link.Delete(l.index)
+	}
+
+	if err := conn.Link.Enslave(vxlanIndex, bridgeIndex, nil); err != nil {
+		t.Fatalf("failed to enslave vxlan: %v", err)
+	}
+
+	// Enable VLAN tunneling on the port, `bridge link set vlan_tunnel on`.
+	hdr := make([]byte, unix.SizeofIfInfomsg)
+	hdr[0] = unix.AF_BRIDGE
+	nativeEndian.PutUint32(hdr[4:8], vxlanIndex)
+	protinfo := encode(func(ae *netlink.AttributeEncoder) {
+		ae.Nested(unix.IFLA_PROTINFO, func(nae *netlink.AttributeEncoder) error {
+			nae.Uint8(unix.IFLA_BRPORT_VLAN_TUNNEL, 1)
+			return nil
+		})
+	})
+	if _, err := conn.c.Execute(netlink.Message{
+		Header: netlink.Header{
+			Type:  unix.RTM_SETLINK,
+			Flags: netlink.Request | netlink.Acknowledge,
+		},
+		Data: append(hdr, protinfo...),
+	}); err != nil {
+		t.Fatalf("failed to enable VLAN tunneling: %v", err)
+	}
+
+	if err := conn.BridgeVlan.Add(vxlanIndex, 100, 0); err != nil {
+		t.Fatalf("failed to add VLAN to port: %v", err)
+	}
+	if err := conn.BridgeVlan.AddTunnel(vxlanIndex, 100, 10100); err != nil {
+		t.Fatalf("failed to map VLAN to VNI: %v", err)
+	}
+
+	tunnels := func() []BridgeVlanTunnelInfo {
+		t.Helper()
+
+		msgs, err := conn.BridgeVlan.List()
+		if err != nil {
+			t.Fatalf("failed to list bridge VLANs: %v", err)
+		}
+		for _, m := range msgs {
+			if m.Index == vxlanIndex {
+				return m.Tunnels
+			}
+		}
+		return nil
+	}
+
+	want := []BridgeVlanTunnelInfo{{VID: 100, TunnelID: 10100}}
+	if diff := cmp.Diff(want, tunnels()); diff != "" {
+		t.Fatalf("unexpected VLAN tunnels (-want +got):\n%s", diff)
+	}
+
+	if err := conn.BridgeVlan.DeleteTunnel(vxlanIndex, 100, 10100); err != nil {
+		t.Fatalf("failed to delete VLAN to VNI mapping: %v", err)
+	}
+	if got := tunnels(); len(got) != 0 {
+		t.Fatalf("unexpected VLAN tunnels after delete: %v", got)
+	}
+}
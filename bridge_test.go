@@ -0,0 +1,294 @@
+package rtnetlink
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Tests will only pass on little endian machines
+
+func TestBridgeVlanInfoMarshalBinary(t *testing.T) {
+	skipBigEndian(t)
+
+	tests := []struct {
+		name string
+		v    BridgeVlanInfo
+		b    []byte
+	}{
+		{
+			name: "vid",
+			v:    BridgeVlanInfo{VID: 10},
+			b:    []byte{0x00, 0x00, 0x0a, 0x00},
+		},
+		{
+			name: "pvid untagged",
+			v: BridgeVlanInfo{
+				Flags: BridgeVlanFlagPVID | BridgeVlanFlagUntagged,
+				VID:   4094,
+			},
+			b: []byte{0x06, 0x00, 0xfe, 0x0f},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.v.marshalBinary()
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("unexpected bytes:\n- want: [%# x]\n-  got: [%# x]", want, got)
+			}
+
+			var v BridgeVlanInfo
+			if err := v.unmarshalBinary(b); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			if diff := cmp.Diff(tt.v, v); diff != "" {
+				t.Fatalf("unexpected bridge vlan info (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if err := (&BridgeVlanInfo{}).unmarshalBinary([]byte{0x00, 0x00}); err == nil {
+		t.Fatal("expected an error for a short bridge vlan info, but none occurred")
+	}
+}
+
+func TestBridgeVlanMessageMarshalBinary(t *testing.T) {
+	skipBigEndian(t)
+
+	vlans, err := bridgeVlanRange(20, 22, BridgeVlanFlagUntagged)
+	if err != nil {
+		t.Fatalf("failed to build VLAN range: %v", err)
+	}
+
+	tunnels, err := bridgeVlanTunnelRange(100, 101, 10100)
+	if err != nil {
+		t.Fatalf("failed to build VLAN tunnel range: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		m    *BridgeVlanMessage
+		b    []byte
+	}{
+		{
+			name: "master",
+			m: &BridgeVlanMessage{
+				Index: 2,
+				Vlans: []BridgeVlanInfo{{VID: 10}},
+			},
+			b: []byte{
+				0x07, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				// IFLA_AF_SPEC
+				0x14, 0x00, 0x1a, 0x80,
+				// IFLA_BRIDGE_FLAGS: BRIDGE_FLAGS_MASTER
+				0x06, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+				// IFLA_BRIDGE_VLAN_INFO
+				0x08, 0x00, 0x02, 0x00, 0x00, 0x00, 0x0a, 0x00,
+			},
+		},
+		{
+			name: "self range",
+			m: &BridgeVlanMessage{
+				Index:  3,
+				Target: BridgeVlanTargetSelf,
+				Vlans:  vlans,
+			},
+			b: []byte{
+				0x07, 0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				// IFLA_AF_SPEC
+				0x1c, 0x00, 0x1a, 0x80,
+				// IFLA_BRIDGE_FLAGS: BRIDGE_FLAGS_SELF
+				0x06, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+				// IFLA_BRIDGE_VLAN_INFO: untagged, range begin
+				0x08, 0x00, 0x02, 0x00, 0x0c, 0x00, 0x14, 0x00,
+				// IFLA_BRIDGE_VLAN_INFO: untagged, range end
+				0x08, 0x00, 0x02, 0x00, 0x14, 0x00, 0x16, 0x00,
+			},
+		},
+		{
+			name: "master tunnel range",
+			m: &BridgeVlanMessage{
+				Index:   4,
+				Tunnels: tunnels,
+			},
+			b: []byte{
+				0x07, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				// IFLA_AF_SPEC
+				0x44, 0x00, 0x1a, 0x80,
+				// IFLA_BRIDGE_FLAGS: BRIDGE_FLAGS_MASTER
+				0x06, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+				// IFLA_BRIDGE_VLAN_TUNNEL_INFO
+				0x1c, 0x00, 0x03, 0x80,
+				// IFLA_BRIDGE_VLAN_TUNNEL_ID: 10100
+				0x08, 0x00, 0x01, 0x00, 0x74, 0x27, 0x00, 0x00,
+				// IFLA_BRIDGE_VLAN_TUNNEL_VID: 100
+				0x06, 0x00, 0x02, 0x00, 0x64, 0x00, 0x00, 0x00,
+				// IFLA_BRIDGE_VLAN_TUNNEL_FLAGS: range begin
+				0x06, 0x00, 0x03, 0x00, 0x08, 0x00, 0x00, 0x00,
+				// IFLA_BRIDGE_VLAN_TUNNEL_INFO
+				0x1c, 0x00, 0x03, 0x80,
+				// IFLA_BRIDGE_VLAN_TUNNEL_ID: 10101
+				0x08, 0x00, 0x01, 0x00, 0x75, 0x27, 0x00, 0x00,
+				// IFLA_BRIDGE_VLAN_TUNNEL_VID: 101
+				0x06, 0x00, 0x02, 0x00, 0x65, 0x00, 0x00, 0x00,
+				// IFLA_BRIDGE_VLAN_TUNNEL_FLAGS: range end
+				0x06, 0x00, 0x03, 0x00, 0x10, 0x00, 0x00, 0x00,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.m.MarshalBinary()
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("unexpected bytes:\n- want: [%# x]\n-  got: [%# x]", want, got)
+			}
+
+			var m BridgeVlanMessage
+			if err := m.UnmarshalBinary(b); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			// The target is not reported back by the kernel.
+			want := *tt.m
+			want.Target = BridgeVlanTargetMaster
+			if diff := cmp.Diff(&want, &m); diff != "" {
+				t.Fatalf("unexpected bridge vlan message (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBridgeVlanRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to uint16
+		flags    BridgeVlanFlags
+		vlans    []BridgeVlanInfo
+		ok       bool
+	}{
+		{
+			name:  "single",
+			from:  10,
+			to:    10,
+			flags: BridgeVlanFlagPVID | BridgeVlanFlagRangeEnd,
+			vlans: []BridgeVlanInfo{{Flags: BridgeVlanFlagPVID, VID: 10}},
+			ok:    true,
+		},
+		{
+			name: "range",
+			from: 1,
+			to:   4094,
+			vlans: []BridgeVlanInfo{
+				{Flags: BridgeVlanFlagRangeBegin, VID: 1},
+				{Flags: BridgeVlanFlagRangeEnd, VID: 4094},
+			},
+			ok: true,
+		},
+		{
+			name: "reversed",
+			from: 20,
+			to:   10,
+		},
+		{
+			name: "zero",
+			from: 0,
+			to:   10,
+		},
+		{
+			name: "reserved",
+			from: 10,
+			to:   4095,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vlans, err := bridgeVlanRange(tt.from, tt.to, tt.flags)
+			if !tt.ok {
+				if err == nil {
+					t.Fatal("expected an error, but none occurred")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to build VLAN range: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.vlans, vlans); diff != "" {
+				t.Fatalf("unexpected VLANs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBridgeVlanTunnelRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to uint16
+		tunnelID uint32
+		tunnels  []BridgeVlanTunnelInfo
+		ok       bool
+	}{
+		{
+			name:     "single",
+			from:     100,
+			to:       100,
+			tunnelID: 10100,
+			tunnels:  []BridgeVlanTunnelInfo{{VID: 100, TunnelID: 10100}},
+			ok:       true,
+		},
+		{
+			name:     "range",
+			from:     100,
+			to:       199,
+			tunnelID: 10100,
+			tunnels: []BridgeVlanTunnelInfo{
+				{Flags: BridgeVlanFlagRangeBegin, VID: 100, TunnelID: 10100},
+				{Flags: BridgeVlanFlagRangeEnd, VID: 199, TunnelID: 10199},
+			},
+			ok: true,
+		},
+		{
+			name:     "reversed",
+			from:     199,
+			to:       100,
+			tunnelID: 10100,
+		},
+		{
+			name:     "tunnel ID overflow",
+			from:     100,
+			to:       199,
+			tunnelID: math.MaxUint32 - 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tunnels, err := bridgeVlanTunnelRange(tt.from, tt.to, tt.tunnelID)
+			if !tt.ok {
+				if err == nil {
+					t.Fatal("expected an error, but none occurred")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to build VLAN tunnel range: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.tunnels, tunnels); diff != "" {
+				t.Fatalf("unexpected VLAN tunnels (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package rtnetlink
 
 import (
+	"context"
 	"encoding"
+	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
@@ -12,12 +16,20 @@ import (
 // A Conn is a route netlink connection. A Conn can be used to send and
 // receive route netlink messages to and from netlink.
 type Conn struct {
-	c       conn
+	c conn
+
+	// mu is held for writing by batches, which send several requests and
+	// receive their replies in separate calls, and for reading by all other
+	// requests, so that they cannot take the replies of a batch.
+	mu sync.RWMutex
+
 	Link    *LinkService
 	Address *AddressService
 	Route   *RouteService
 	Neigh   *NeighService
 	Rule    *RuleService
+
+	BridgeVlan *BridgeVlanService
 }
 
 var _ conn = &netlink.Conn{}
@@ -26,17 +38,31 @@ var _ conn = &netlink.Conn{}
 type conn interface {
 	Close() error
 	Send(m netlink.Message) (netlink.Message, error)
+	SendMessages(m []netlink.Message) ([]netlink.Message, error)
 	Receive() ([]netlink.Message, error)
 	Execute(m netlink.Message) ([]netlink.Message, error)
+	JoinGroup(group uint32) error
+	LeaveGroup(group uint32) error
 	SetOption(option netlink.ConnOption, enable bool) error
 	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+}
+
+// dial creates the underlying netlink connection. It is swapped in tests.
+var dial = func(family int, config *netlink.Config) (conn, error) {
+	return netlink.Dial(family, config)
 }
 
 // Dial dials a route netlink connection.  Config specifies optional
 // configuration for the underlying netlink connection.  If config is
 // nil, a default configuration will be used.
+//
+// Config.Groups binds the connection to a bitmask of the legacy RTMGRP_*
+// multicast groups at dial time, and Config.NetNS selects the network
+// namespace to operate in. Groups can also be joined and left after dialing
+// using JoinGroup and LeaveGroup.
 func Dial(config *netlink.Config) (*Conn, error) {
-	c, err := netlink.Dial(unix.NETLINK_ROUTE, config)
+	c, err := dial(unix.NETLINK_ROUTE, config)
 	if err != nil {
 		return nil, err
 	}
@@ -56,6 +82,7 @@ func newConn(c conn) *Conn {
 	rtc.Route = &RouteService{c: rtc}
 	rtc.Neigh = &NeighService{c: rtc}
 	rtc.Rule = &RuleService{c: rtc}
+	rtc.BridgeVlan = &BridgeVlanService{c: rtc}
 
 	return rtc
 }
@@ -65,6 +92,58 @@ func (c *Conn) Close() error {
 	return c.c.Close()
 }
 
+// JoinGroup joins a netlink multicast group by its ID (one of the
+// RTNLGRP_* values), so that notifications of that group can be received
+// using Receive.
+func (c *Conn) JoinGroup(group uint32) error {
+	return c.c.JoinGroup(group)
+}
+
+// Route netlink multicast groups, for use with Listen, JoinGroup and
+// LeaveGroup.
+const (
+	// Link changes, RTM_NEWLINK and RTM_DELLINK notifications
+	GroupLink uint32 = unix.RTNLGRP_LINK
+
+	// IPv4 address changes, RTM_NEWADDR and RTM_DELADDR notifications
+	GroupIPv4Address uint32 = unix.RTNLGRP_IPV4_IFADDR
+
+	// IPv6 address changes, RTM_NEWADDR and RTM_DELADDR notifications
+	GroupIPv6Address uint32 = unix.RTNLGRP_IPV6_IFADDR
+
+	// IPv4 route changes, RTM_NEWROUTE and RTM_DELROUTE notifications
+	GroupIPv4Route uint32 = unix.RTNLGRP_IPV4_ROUTE
+
+	// IPv6 route changes, RTM_NEWROUTE and RTM_DELROUTE notifications
+	GroupIPv6Route uint32 = unix.RTNLGRP_IPV6_ROUTE
+
+	// Neighbor changes, RTM_NEWNEIGH and RTM_DELNEIGH notifications
+	GroupNeigh uint32 = unix.RTNLGRP_NEIGH
+)
+
+// Listen joins all of the given multicast groups, such as GroupLink, so
+// that their notifications can be received using Receive. Receive returns
+// the notifications as typed Messages, e.g. a *LinkMessage for RTM_NEWLINK.
+// If a group cannot be joined, the groups joined by this call are left again
+// and the error is returned.
+func (c *Conn) Listen(groups ...uint32) error {
+	for i, g := range groups {
+		if err := c.c.JoinGroup(g); err != nil {
+			for _, j := range groups[:i] {
+				_ = c.c.LeaveGroup(j)
+			}
+			return fmt.Errorf("rtnetlink: failed to join group %d: %w", g, err)
+		}
+	}
+
+	return nil
+}
+
+// LeaveGroup leaves a netlink multicast group by its ID.
+func (c *Conn) LeaveGroup(group uint32) error {
+	return c.c.LeaveGroup(group)
+}
+
 // SetOption enables or disables a netlink socket option for the Conn.
 func (c *Conn) SetOption(option netlink.ConnOption, enable bool) error {
 	return c.c.SetOption(option, enable)
@@ -127,12 +206,88 @@ func (c *Conn) Receive() ([]Message, []netlink.Message, error) {
 // See the documentation of Send, Receive, and netlink.Validate for details
 // about each function.
 func (c *Conn) Execute(m Message, family uint16, flags netlink.HeaderFlags) ([]Message, error) {
+	rtmsgs, _, err := c.ExecuteRaw(m, family, flags)
+	return rtmsgs, err
+}
+
+// ExecuteRaw is like Execute, but also returns the raw netlink.Messages of
+// the replies, in the same way as Receive. This gives access to the netlink
+// headers of the replies, such as their sequence numbers and flags.
+func (c *Conn) ExecuteRaw(m Message, family uint16, flags netlink.HeaderFlags) ([]Message, []netlink.Message, error) {
 	nm, err := packMessage(m, family, flags)
 	if err != nil {
+		return nil, nil, err
+	}
+
+	msgs, err := c.execute(nm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rtmsgs, err := unpackMessages(msgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rtmsgs, msgs, nil
+}
+
+// ErrStopList can be returned by the callback of a ListFunc method to stop
+// the iteration early. The ListFunc method then returns nil. The dump has
+// already been received in full at that point, so ErrStopList only skips
+// decoding the remaining messages.
+var ErrStopList = errors.New("rtnetlink: stop listing")
+
+// executeFunc is like Execute, but instead of unpacking all replies up front
+// it unpacks them one at a time and passes each of them to fn. The raw
+// replies are still received in full by the underlying connection, which
+// reads a multipart dump until its end before returning it.
+func (c *Conn) executeFunc(m Message, family uint16, flags netlink.HeaderFlags, fn func(Message) error) error {
+	nm, err := packMessage(m, family, flags)
+	if err != nil {
+		return err
+	}
+
+	msgs, err := c.execute(nm)
+	if err != nil {
+		return err
+	}
+
+	for _, nm := range msgs {
+		m, err := unpackMessage(nm)
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			continue
+		}
+
+		if err := fn(m); err != nil {
+			if errors.Is(err, ErrStopList) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExecuteContext is like Execute, but the request is bounded by ctx. The
+// deadline of ctx is applied to the underlying socket, and the request is
+// aborted as soon as ctx is cancelled, in which case the error of ctx is
+// returned.
+func (c *Conn) ExecuteContext(ctx context.Context, m Message, family uint16, flags netlink.HeaderFlags) ([]Message, error) {
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	msgs, err := c.c.Execute(nm)
+	nm, err := packMessage(m, family, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := c.executeContext(ctx, nm)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +295,160 @@ func (c *Conn) Execute(m Message, family uint16, flags netlink.HeaderFlags) ([]M
 	return unpackMessages(msgs)
 }
 
+// executeContext executes nm on the underlying connection, bounded by ctx.
+func (c *Conn) executeContext(ctx context.Context, nm netlink.Message) ([]netlink.Message, error) {
+	// Contexts that can never be done, such as context.Background, need no
+	// deadline handling at all.
+	if ctx.Done() == nil {
+		return c.execute(nm)
+	}
+
+	deadline, _ := ctx.Deadline()
+	if err := c.setDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	stop := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-ctx.Done():
+			// Move the deadline into the past to unblock the pending request.
+			_ = c.setDeadline(time.Unix(1, 0))
+		case <-stop:
+		}
+	}()
+
+	msgs, err := c.execute(nm)
+
+	// Wait for the watcher to exit before clearing the deadline, so that it
+	// cannot be set again afterwards.
+	close(stop)
+	<-exited
+	_ = c.setDeadline(time.Time{})
+
+	if err != nil {
+		if cerr := ctx.Err(); cerr != nil {
+			return nil, cerr
+		}
+		return nil, err
+	}
+
+	return msgs, nil
+}
+
+// execute executes nm on the underlying connection. It waits for a running
+// batch to finish, so that the replies of the batch are not taken.
+func (c *Conn) execute(nm netlink.Message) ([]netlink.Message, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.c.Execute(nm)
+}
+
+// setDeadline sets the read and write deadlines of the underlying
+// connection. The zero value of t clears them.
+func (c *Conn) setDeadline(t time.Time) error {
+	if err := c.c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.c.SetWriteDeadline(t)
+}
+
+// executeBatch packs all Messages and sends them to netlink in a single write,
+// then receives the acknowledgement of each of them. The returned slice holds
+// the error of each Message in order, or nil if it succeeded.
+//
+// The Conn is locked for the whole batch, so concurrent requests on the Conn
+// wait for it to finish. Replies are matched to the requests by sequence
+// number, and other messages, such as multicast notifications received on a
+// Conn that joined groups, are skipped.
+func (c *Conn) executeBatch(ms []Message, family uint16, flags netlink.HeaderFlags) []error {
+	errs := make([]error, len(ms))
+
+	nms := make([]netlink.Message, 0, len(ms))
+	idx := make([]int, 0, len(ms))
+	for i, m := range ms {
+		nm, err := packMessage(m, family, flags|netlink.Acknowledge)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		nms = append(nms, nm)
+		idx = append(idx, i)
+	}
+
+	if len(nms) == 0 {
+		return errs
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	reqs, err := c.c.SendMessages(nms)
+	if err != nil {
+		for _, i := range idx {
+			errs[i] = err
+		}
+		return errs
+	}
+
+	// The kernel handles the messages in order and acknowledges each of
+	// them separately. A failed request is reported by Receive as an error
+	// without the reply, so it belongs to the oldest request that has not
+	// been acknowledged yet.
+	for len(reqs) > 0 {
+		msgs, err := c.c.Receive()
+		if err != nil {
+			errs[idx[0]] = err
+			reqs, idx = reqs[1:], idx[1:]
+			continue
+		}
+
+		for _, m := range msgs {
+			if m.Header.Type != netlink.Error {
+				continue
+			}
+			for n, req := range reqs {
+				if m.Header.Sequence == req.Header.Sequence {
+					reqs = append(reqs[:n], reqs[n+1:]...)
+					idx = append(idx[:n], idx[n+1:]...)
+					break
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// A BatchError is returned when one or more requests of a batch operation
+// failed. Errors holds the error of each request in the order they were
+// given, with a nil entry for every request that succeeded.
+type BatchError struct {
+	Errors []error
+}
+
+// Error implements error.
+func (e *BatchError) Error() string {
+	var (
+		n     int
+		first error
+	)
+	for _, err := range e.Errors {
+		if err == nil {
+			continue
+		}
+		if first == nil {
+			first = err
+		}
+		n++
+	}
+
+	return fmt.Sprintf("rtnetlink: %d of %d batch requests failed, first error: %v", n, len(e.Errors), first)
+}
+
 // Message is the interface used for passing around different kinds of rtnetlink messages
 type Message interface {
 	encoding.BinaryMarshaler
@@ -171,27 +480,43 @@ func unpackMessages(msgs []netlink.Message) ([]Message, error) {
 	lmsgs := make([]Message, 0, len(msgs))
 
 	for _, nm := range msgs {
-		var m Message
-		switch nm.Header.Type {
-		case unix.RTM_GETLINK, unix.RTM_NEWLINK, unix.RTM_DELLINK:
-			m = &LinkMessage{filtered: (nm.Header.Flags&netlink.DumpFiltered != 0)}
-		case unix.RTM_GETADDR, unix.RTM_NEWADDR, unix.RTM_DELADDR:
-			m = &AddressMessage{}
-		case unix.RTM_GETROUTE, unix.RTM_NEWROUTE, unix.RTM_DELROUTE:
-			m = &RouteMessage{}
-		case unix.RTM_GETNEIGH, unix.RTM_NEWNEIGH, unix.RTM_DELNEIGH:
-			m = &NeighMessage{}
-		case unix.RTM_GETRULE, unix.RTM_NEWRULE, unix.RTM_DELRULE:
-			m = &RuleMessage{}
-		default:
-			continue
-		}
-
-		if err := (m).UnmarshalBinary(nm.Data); err != nil {
+		m, err := unpackMessage(nm)
+		if err != nil {
 			return nil, err
 		}
+		if m == nil {
+			continue
+		}
 		lmsgs = append(lmsgs, m)
 	}
 
 	return lmsgs, nil
 }
+
+// unpackMessage unpacks a single rtnetlink Message from a netlink.Message.
+// A nil Message is returned for message types that are not supported.
+func unpackMessage(nm netlink.Message) (Message, error) {
+	var m Message
+	switch nm.Header.Type {
+	case unix.RTM_GETLINK, unix.RTM_NEWLINK, unix.RTM_DELLINK:
+		m = &LinkMessage{filtered: (nm.Header.Flags&netlink.DumpFiltered != 0)}
+	case unix.RTM_GETADDR, unix.RTM_NEWADDR, unix.RTM_DELADDR:
+		m = &AddressMessage{}
+	case unix.RTM_GETROUTE, unix.RTM_NEWROUTE, unix.RTM_DELROUTE:
+		m = &RouteMessage{}
+	case unix.RTM_GETNEIGH, unix.RTM_NEWNEIGH, unix.RTM_DELNEIGH:
+		m = &NeighMessage{}
+	case unix.RTM_GETRULE, unix.RTM_NEWRULE, unix.RTM_DELRULE:
+		m = &RuleMessage{}
+	case unix.RTM_GETNSID, unix.RTM_NEWNSID, unix.RTM_DELNSID:
+		m = &NetNSIDMessage{}
+	default:
+		return nil, nil
+	}
+
+	if err := m.UnmarshalBinary(nm.Data); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
@@ -4,10 +4,15 @@
 package rtnetlink
 
 import (
+	"context"
 	"encoding"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -74,6 +79,36 @@ func TestConnExecute(t *testing.T) {
 	}
 }
 
+func TestConnExecuteRaw(t *testing.T) {
+	skipBigEndian(t)
+
+	c, tc := testConn(t)
+	tc.receive = []netlink.Message{{
+		Header: netlink.Header{
+			Length:   16,
+			Type:     unix.RTM_NEWLINK,
+			Flags:    netlink.Multi,
+			Sequence: 10,
+		},
+		Data: []byte{
+			0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		},
+	}}
+
+	rtmsgs, nlmsgs, err := c.ExecuteRaw(&LinkMessage{}, unix.RTM_GETLINK, netlink.Request|netlink.Dump)
+	if err != nil {
+		t.Fatalf("failed to execute: %v", err)
+	}
+
+	if want, got := []Message{&LinkMessage{Index: 2}}, rtmsgs; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected replies:\n- want: %#v\n-  got: %#v", want, got)
+	}
+	if want, got := tc.receive, nlmsgs; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected netlink.Messages:\n- want: %#v\n-  got: %#v", want, got)
+	}
+}
+
 func TestConnSend(t *testing.T) {
 	skipBigEndian(t)
 
@@ -180,6 +215,913 @@ func TestConnReceive(t *testing.T) {
 	}
 }
 
+func TestAddressServiceAddBatch(t *testing.T) {
+	errExists := errors.New("file exists")
+
+	c := &testBatchConn{
+		// One reply per message sent; the invalid message is never sent.
+		receive: []error{nil, errExists, nil},
+	}
+	conn := newConn(c)
+
+	reqs := []*AddressMessage{
+		{Family: unix.AF_INET, PrefixLength: 24, Index: 1},
+		{Family: unix.AF_INET, PrefixLength: 24, Index: 2},
+		{Family: unix.AF_INET, PrefixLength: 33, Index: 3},
+		{Family: unix.AF_INET6, PrefixLength: 64, Index: 4},
+	}
+
+	err := conn.Address.AddBatch(reqs)
+
+	var berr *BatchError
+	if !errors.As(err, &berr) {
+		t.Fatalf("expected a *BatchError, got: %v", err)
+	}
+
+	if want, got := 3, len(c.sent); want != got {
+		t.Fatalf("unexpected number of sent messages, want: %d, got: %d", want, got)
+	}
+
+	for i, idx := range []int{0, 1, 3} {
+		if want, got := mustMarshal(reqs[idx]), c.sent[i].Data; !reflect.DeepEqual(want, got) {
+			t.Fatalf("unexpected message %d data:\n- want: %v\n-  got: %v", i, want, got)
+		}
+
+		flags := netlink.Request | netlink.Create | netlink.Acknowledge | netlink.Excl
+		if want, got := flags, c.sent[i].Header.Flags; want != got {
+			t.Fatalf("unexpected message %d flags, want: %s, got: %s", i, want, got)
+		}
+	}
+
+	if len(berr.Errors) != len(reqs) {
+		t.Fatalf("unexpected number of errors, want: %d, got: %d", len(reqs), len(berr.Errors))
+	}
+	if berr.Errors[0] != nil || berr.Errors[3] != nil {
+		t.Fatalf("unexpected errors for successful requests: %v", berr.Errors)
+	}
+	if berr.Errors[1] != errExists {
+		t.Fatalf("unexpected error for request 1: %v", berr.Errors[1])
+	}
+	if berr.Errors[2] == nil {
+		t.Fatal("expected a validation error for request 2")
+	}
+}
+
+func TestAddressServiceAddBatchInterleaved(t *testing.T) {
+	errExists := errors.New("file exists")
+
+	c := &testBatchConn{
+		receive: []error{nil, errExists},
+		notify: []netlink.Message{
+			// The notification for the first address carries the sequence
+			// number of its request.
+			{
+				Header: netlink.Header{Type: unix.RTM_NEWADDR, Sequence: 1},
+				Data:   mustMarshal(&AddressMessage{Family: unix.AF_INET, PrefixLength: 24, Index: 1}),
+			},
+			// A stale acknowledgement of an unrelated request.
+			{
+				Header: netlink.Header{Type: netlink.Error, Sequence: 100},
+				Data:   make([]byte, 4),
+			},
+		},
+	}
+	conn := newConn(c)
+
+	err := conn.Address.AddBatch([]*AddressMessage{
+		{Family: unix.AF_INET, PrefixLength: 24, Index: 1},
+		{Family: unix.AF_INET, PrefixLength: 24, Index: 2},
+	})
+
+	var berr *BatchError
+	if !errors.As(err, &berr) {
+		t.Fatalf("expected a *BatchError, got: %v", err)
+	}
+	if want, got := []error{nil, errExists}, berr.Errors; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected errors, want: %v, got: %v", want, got)
+	}
+	if len(c.notify) != 0 || len(c.receive) != 0 {
+		t.Fatal("not all messages were received")
+	}
+}
+
+func TestAddressServiceNewReplace(t *testing.T) {
+	skipBigEndian(t)
+
+	req := &AddressMessage{
+		Family:       unix.AF_INET,
+		PrefixLength: 24,
+		Index:        2,
+		Attributes: &AddressAttributes{
+			Address: net.IPv4(192, 0, 2, 1),
+		},
+	}
+
+	tests := []struct {
+		name  string
+		fn    func(a *AddressService) error
+		flags netlink.HeaderFlags
+	}{
+		{
+			name:  "new",
+			fn:    func(a *AddressService) error { return a.New(req) },
+			flags: netlink.Request | netlink.Create | netlink.Excl | netlink.Acknowledge,
+		},
+		{
+			name:  "replace",
+			fn:    func(a *AddressService) error { return a.Replace(req) },
+			flags: netlink.Request | netlink.Create | netlink.Replace | netlink.Acknowledge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, tc := testConn(t)
+			if err := tt.fn(c.Address); err != nil {
+				t.Fatalf("failed to execute request: %v", err)
+			}
+
+			if want, got := netlink.HeaderType(unix.RTM_NEWADDR), tc.send.Header.Type; want != got {
+				t.Fatalf("unexpected request type, want: %v, got: %v", want, got)
+			}
+			if want, got := tt.flags, tc.send.Header.Flags; want != got {
+				t.Fatalf("unexpected request flags, want: %v, got: %v", want, got)
+			}
+			if want, got := mustMarshal(req), tc.send.Data; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected request data:\n- want: [%# x]\n-  got: [%# x]", want, got)
+			}
+		})
+	}
+}
+
+func TestAddressServiceGet(t *testing.T) {
+	skipBigEndian(t)
+
+	c, tc := testConn(t)
+	for _, index := range []uint32{1, 2, 3, 2} {
+		tc.receive = append(tc.receive, netlink.Message{
+			Header: netlink.Header{Type: unix.RTM_NEWADDR},
+			Data: mustMarshal(&AddressMessage{
+				Family:       unix.AF_INET,
+				PrefixLength: 24,
+				Index:        index,
+			}),
+		})
+	}
+
+	addrs, err := c.Address.Get(2)
+	if err != nil {
+		t.Fatalf("failed to get addresses: %v", err)
+	}
+
+	if want, got := netlink.Request|netlink.Dump, tc.send.Header.Flags; want != got {
+		t.Fatalf("unexpected request flags, want: %v, got: %v", want, got)
+	}
+	if len(addrs) != 2 {
+		t.Fatalf("unexpected number of addresses, want: 2, got: %d", len(addrs))
+	}
+	for _, a := range addrs {
+		if a.Index != 2 {
+			t.Fatalf("unexpected address of interface %d", a.Index)
+		}
+	}
+}
+
+func TestRouteServiceGet(t *testing.T) {
+	skipBigEndian(t)
+
+	conn, tc := testConn(t)
+
+	routes := []*RouteMessage{
+		{Family: unix.AF_INET, DstLength: 24, Table: unix.RT_TABLE_MAIN},
+		{Family: unix.AF_INET, DstLength: 32, Table: unix.RT_TABLE_LOCAL},
+		{Family: unix.AF_INET6, DstLength: 64, Table: unix.RT_TABLE_MAIN},
+	}
+	for _, r := range routes {
+		tc.receive = append(tc.receive, netlink.Message{
+			Header: netlink.Header{
+				Type: unix.RTM_NEWROUTE,
+			},
+			Data: mustMarshal(r),
+		})
+	}
+
+	got, err := conn.Route.Get(&RouteMessage{Family: unix.AF_INET})
+	if err != nil {
+		t.Fatalf("failed to get routes: %v", err)
+	}
+
+	want := []RouteMessage{*routes[0], *routes[1], *routes[2]}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected routes:\n- want: %#v\n-  got: %#v", want, got)
+	}
+	if want, got := netlink.HeaderType(unix.RTM_GETROUTE), tc.send.Header.Type; want != got {
+		t.Fatalf("unexpected request type, want: %d, got: %d", want, got)
+	}
+}
+
+func TestRouteServiceListByProtocol(t *testing.T) {
+	skipBigEndian(t)
+
+	if err := RegisterRouteProtocol(186, "bird"); err != nil {
+		t.Fatalf("failed to register route protocol: %v", err)
+	}
+	defer delete(registeredRouteProtocols, 186)
+
+	conn, tc := testConn(t)
+
+	routes := []*RouteMessage{
+		{Family: unix.AF_INET, Protocol: unix.RTPROT_KERNEL},
+		{Family: unix.AF_INET, Protocol: 186, DstLength: 24},
+		{Family: unix.AF_INET, Protocol: unix.RTPROT_BOOT},
+		{Family: unix.AF_INET6, Protocol: 186, DstLength: 64},
+	}
+	for _, r := range routes {
+		tc.receive = append(tc.receive, netlink.Message{
+			Header: netlink.Header{
+				Type: unix.RTM_NEWROUTE,
+			},
+			Data: mustMarshal(r),
+		})
+	}
+
+	got, err := conn.Route.ListByProtocol(186)
+	if err != nil {
+		t.Fatalf("failed to list routes: %v", err)
+	}
+
+	want := []RouteMessage{*routes[1], *routes[3]}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected routes:\n- want: %#v\n-  got: %#v", want, got)
+	}
+
+	for _, r := range got {
+		if p := RouteProtocol(r.Protocol); p.String() != "bird" {
+			t.Fatalf("unexpected protocol name: %s", p)
+		}
+	}
+}
+
+func TestLinkServiceDelete(t *testing.T) {
+	skipBigEndian(t)
+
+	conn, tc := testConn(t)
+
+	if err := conn.Link.Delete(2); err != nil {
+		t.Fatalf("failed to delete link: %v", err)
+	}
+
+	want := netlink.Message{
+		Header: netlink.Header{
+			Type:  unix.RTM_DELLINK,
+			Flags: netlink.Request | netlink.Acknowledge,
+		},
+		Data: mustMarshal(&LinkMessage{Index: 2}),
+	}
+	if got := tc.send; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected request:\n- want: %#v\n-  got: %#v", want, got)
+	}
+}
+
+func TestLinkServiceSet(t *testing.T) {
+	skipBigEndian(t)
+
+	conn, tc := testConn(t)
+
+	slaveData := []byte{0x05, 0x00, 0x01, 0x00, 0x03, 0x00, 0x00, 0x00} // IFLA_BRPORT_STATE: forwarding
+	req := &LinkMessage{
+		Index: 2,
+		Attributes: &LinkAttributes{
+			Info: &LinkInfo{
+				SlaveKind: "bridge",
+				SlaveData: &LinkData{Name: "bridge", Data: slaveData, Slave: true},
+			},
+			XDP: &LinkXDP{
+				FD:         3,
+				ExpectedFD: -1,
+				Flags:      unix.XDP_FLAGS_REPLACE,
+			},
+		},
+	}
+	if err := conn.Link.Set(req); err != nil {
+		t.Fatalf("failed to set link: %v", err)
+	}
+
+	wantHdr := netlink.Header{
+		Type:  unix.RTM_NEWLINK,
+		Flags: netlink.Request | netlink.Acknowledge,
+	}
+	if got := tc.send.Header; !reflect.DeepEqual(wantHdr, got) {
+		t.Fatalf("unexpected header:\n- want: %#v\n-  got: %#v", wantHdr, got)
+	}
+
+	ad, err := netlink.NewAttributeDecoder(tc.send.Data[unix.SizeofIfInfomsg:])
+	if err != nil {
+		t.Fatalf("failed to decode attributes: %v", err)
+	}
+
+	var (
+		slaveKind string
+		gotData   []byte
+		xdp       LinkXDP
+	)
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_LINKINFO:
+			ad.Nested(func(nad *netlink.AttributeDecoder) error {
+				for nad.Next() {
+					switch nad.Type() {
+					case unix.IFLA_INFO_SLAVE_KIND:
+						slaveKind = nad.String()
+					case unix.IFLA_INFO_SLAVE_DATA:
+						gotData = nad.Bytes()
+					}
+				}
+				return nil
+			})
+		case unix.IFLA_XDP:
+			ad.Nested(xdp.decode)
+		}
+	}
+	if err := ad.Err(); err != nil {
+		t.Fatalf("failed to decode attributes: %v", err)
+	}
+
+	if want, got := "bridge", slaveKind; want != got {
+		t.Fatalf("unexpected slave kind, want: %q, got: %q", want, got)
+	}
+	if want, got := slaveData, gotData; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected slave data:\n- want: [%# x]\n-  got: [%# x]", want, got)
+	}
+	if want, got := *req.Attributes.XDP, xdp; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected XDP attributes:\n- want: %#v\n-  got: %#v", want, got)
+	}
+}
+
+func TestLinkServiceGetByName(t *testing.T) {
+	skipBigEndian(t)
+
+	reply := netlink.Message{
+		Header: netlink.Header{Type: unix.RTM_NEWLINK},
+		Data: mustMarshal(&LinkMessage{
+			Index:      2,
+			Attributes: &LinkAttributes{Name: "eth0"},
+		}),
+	}
+	enodev := &netlink.OpError{Op: "receive", Err: unix.ENODEV}
+
+	tests := []struct {
+		name  string
+		ifc   string
+		errs  []error
+		types []uint16
+	}{
+		{
+			name:  "name",
+			ifc:   "eth0",
+			errs:  []error{nil},
+			types: []uint16{unix.IFLA_IFNAME},
+		},
+		{
+			name:  "alternative name",
+			ifc:   "uplink",
+			errs:  []error{enodev, nil},
+			types: []uint16{unix.IFLA_IFNAME, unix.IFLA_ALT_IFNAME},
+		},
+		{
+			name:  "long alternative name",
+			ifc:   "enp0s31f6-management-uplink",
+			errs:  []error{nil},
+			types: []uint16{unix.IFLA_ALT_IFNAME},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := &testNameConn{errs: tt.errs, reply: reply}
+			conn := newConn(tc)
+
+			m, err := conn.Link.GetByName(tt.ifc)
+			if err != nil {
+				t.Fatalf("failed to get link by name: %v", err)
+			}
+			if m.Index != 2 || m.Attributes.Name != "eth0" {
+				t.Fatalf("unexpected link: %#v", m)
+			}
+
+			var types []uint16
+			for _, nm := range tc.sent {
+				if want, got := netlink.HeaderType(unix.RTM_GETLINK), nm.Header.Type; want != got {
+					t.Fatalf("unexpected request type, want: %d, got: %d", want, got)
+				}
+
+				ad, err := netlink.NewAttributeDecoder(nm.Data[unix.SizeofIfInfomsg:])
+				if err != nil {
+					t.Fatalf("failed to decode attributes: %v", err)
+				}
+				for ad.Next() {
+					if want, got := tt.ifc, ad.String(); want != got {
+						t.Fatalf("unexpected name, want: %q, got: %q", want, got)
+					}
+					types = append(types, ad.Type())
+				}
+			}
+			if want, got := tt.types, types; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected name attributes, want: %v, got: %v", want, got)
+			}
+		})
+	}
+
+	tc := &testNameConn{errs: []error{enodev, enodev}}
+	if _, err := newConn(tc).Link.GetByName("missing"); !errors.Is(err, unix.ENODEV) {
+		t.Fatalf("expected ENODEV, got: %v", err)
+	}
+}
+
+func TestLinkServiceAltName(t *testing.T) {
+	skipBigEndian(t)
+
+	tests := []struct {
+		name string
+		fn   func(l *LinkService) error
+		typ  netlink.HeaderType
+	}{
+		{
+			name: "add",
+			fn:   func(l *LinkService) error { return l.AddAltName(2, "uplink") },
+			typ:  unix.RTM_NEWLINKPROP,
+		},
+		{
+			name: "delete",
+			fn:   func(l *LinkService) error { return l.DelAltName(2, "uplink") },
+			typ:  unix.RTM_DELLINKPROP,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, tc := testConn(t)
+			if err := tt.fn(c.Link); err != nil {
+				t.Fatalf("failed to change alternative name: %v", err)
+			}
+
+			if want, got := tt.typ, tc.send.Header.Type; want != got {
+				t.Fatalf("unexpected request type, want: %v, got: %v", want, got)
+			}
+			if want, got := netlink.Request|netlink.Acknowledge, tc.send.Header.Flags; want != got {
+				t.Fatalf("unexpected request flags, want: %v, got: %v", want, got)
+			}
+
+			want := []byte{
+				0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				// IFLA_PROP_LIST
+				0x10, 0x00, 0x34, 0x80,
+				// IFLA_ALT_IFNAME
+				0x0b, 0x00, 0x35, 0x00, 0x75, 0x70, 0x6c, 0x69,
+				0x6e, 0x6b, 0x00, 0x00,
+			}
+			if got := tc.send.Data; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected request data:\n- want: [%# x]\n-  got: [%# x]", want, got)
+			}
+		})
+	}
+
+	c, _ := testConn(t)
+	for _, name := range []string{"", strings.Repeat("a", 128)} {
+		if err := c.Link.AddAltName(2, name); err == nil {
+			t.Fatalf("expected an error for alternative name %q, but none occurred", name)
+		}
+	}
+}
+
+func TestNeighServiceNewDelete(t *testing.T) {
+	skipBigEndian(t)
+
+	req := &NeighMessage{
+		Family: unix.AF_INET,
+		Index:  2,
+		State:  unix.NUD_PERMANENT,
+		Flags:  unix.NTF_ROUTER,
+		Type:   unix.RTN_UNICAST,
+		Attributes: &NeighAttributes{
+			Address:   net.IPv4(192, 0, 2, 1).To4(),
+			LLAddress: net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0x00, 0x01},
+			IfIndex:   2,
+		},
+	}
+
+	want := []byte{
+		// ndmsg: family, index, state, flags and type
+		0x02, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+		0x80, 0x00, 0x80, 0x01,
+		// NDA_UNSPEC
+		0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		// NDA_DST
+		0x08, 0x00, 0x01, 0x00, 0xc0, 0x00, 0x02, 0x01,
+		// NDA_LLADDR
+		0x0a, 0x00, 0x02, 0x00, 0xde, 0xad, 0xbe, 0xef,
+		0x00, 0x01, 0x00, 0x00,
+		// NDA_IFINDEX
+		0x08, 0x00, 0x08, 0x00, 0x02, 0x00, 0x00, 0x00,
+	}
+
+	tests := []struct {
+		name string
+		fn   func(*NeighService) error
+		typ  netlink.HeaderType
+	}{
+		{
+			name: "new",
+			fn:   func(s *NeighService) error { return s.New(req) },
+			typ:  unix.RTM_NEWNEIGH,
+		},
+		{
+			name: "delete",
+			fn:   func(s *NeighService) error { return s.Delete(req) },
+			typ:  unix.RTM_DELNEIGH,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, tc := testConn(t)
+
+			if err := tt.fn(conn.Neigh); err != nil {
+				t.Fatalf("failed to execute request: %v", err)
+			}
+
+			if want, got := tt.typ, tc.send.Header.Type; want != got {
+				t.Fatalf("unexpected request type, want: %d, got: %d", want, got)
+			}
+			if got := tc.send.Data; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected request bytes:\n- want: [%# x]\n-  got: [%# x]", want, got)
+			}
+		})
+	}
+
+	conn, _ := testConn(t)
+	if err := conn.Neigh.Delete(&NeighMessage{Family: unix.AF_INET}); err == nil {
+		t.Fatal("expected an error for a request without an interface index, but none occurred")
+	}
+}
+
+func TestDialConfig(t *testing.T) {
+	var (
+		family int
+		config *netlink.Config
+		tc     = &testGroupConn{}
+	)
+
+	defer func(d func(int, *netlink.Config) (conn, error)) { dial = d }(dial)
+	dial = func(f int, cfg *netlink.Config) (conn, error) {
+		family, config = f, cfg
+		return tc, nil
+	}
+
+	want := &netlink.Config{Groups: 0x1 | 0x10}
+	c, err := Dial(want)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+
+	if family != unix.NETLINK_ROUTE {
+		t.Fatalf("unexpected netlink family: %d", family)
+	}
+	if config != want {
+		t.Fatalf("config was not passed to the dialer:\n- want: %#v\n-  got: %#v", want, config)
+	}
+
+	if err := c.JoinGroup(unix.RTNLGRP_IPV4_ROUTE); err != nil {
+		t.Fatalf("failed to join group: %v", err)
+	}
+	if err := c.LeaveGroup(unix.RTNLGRP_LINK); err != nil {
+		t.Fatalf("failed to leave group: %v", err)
+	}
+
+	if want, got := []uint32{unix.RTNLGRP_IPV4_ROUTE}, tc.joined; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected joined groups, want: %v, got: %v", want, got)
+	}
+	if want, got := []uint32{unix.RTNLGRP_LINK}, tc.left; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected left groups, want: %v, got: %v", want, got)
+	}
+}
+
+func TestNSManager(t *testing.T) {
+	var dialed []*netlink.Config
+
+	defer func(d func(int, *netlink.Config) (conn, error)) { dial = d }(dial)
+	dial = func(_ int, cfg *netlink.Config) (conn, error) {
+		dialed = append(dialed, cfg)
+		return &testCloseConn{}, nil
+	}
+
+	ids := map[int]string{10: "4:1", 11: "4:1", 12: "4:2"}
+	defer func(f func(int) (string, error)) { netNSIdentity = f }(netNSIdentity)
+	netNSIdentity = func(fd int) (string, error) {
+		id, ok := ids[fd]
+		if !ok {
+			return "", fmt.Errorf("bad fd %d", fd)
+		}
+		return id, nil
+	}
+
+	var m NSManager
+
+	root, err := m.Conn(nil)
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+	ns1, err := m.Conn(NetNSForFD(10))
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+	if root == ns1 {
+		t.Fatal("expected different connections for different namespaces")
+	}
+
+	again, err := m.Conn(NetNSForFD(10))
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+	if again != ns1 {
+		t.Fatal("expected the same connection for the same namespace")
+	}
+
+	// A different fd referring to the same namespace shares the connection.
+	same, err := m.Conn(NetNSForFD(11))
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+	if same != ns1 {
+		t.Fatal("expected the same connection for another fd of the same namespace")
+	}
+
+	if _, err := m.Conn(NetNSForFD(13)); err == nil {
+		t.Fatal("expected an error for an fd without a namespace")
+	}
+
+	if want, got := 2, len(dialed); want != got {
+		t.Fatalf("unexpected number of dials, want: %d, got: %d", want, got)
+	}
+	if dialed[0] != nil {
+		t.Fatalf("unexpected config for the current namespace: %#v", dialed[0])
+	}
+	if want, got := 10, dialed[1].NetNS; want != got {
+		t.Fatalf("unexpected netns fd, want: %d, got: %d", want, got)
+	}
+
+	if err := m.Close(); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+	for _, c := range []*Conn{root, ns1} {
+		if !c.c.(*testCloseConn).closed {
+			t.Fatal("connection was not closed")
+		}
+	}
+
+	reopened, err := m.Conn(NetNSForFD(10))
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+	if reopened == ns1 {
+		t.Fatal("expected a new connection after Close")
+	}
+
+	// The fd number is reused for another namespace.
+	ids[10] = "4:2"
+	reused, err := m.Conn(NetNSForFD(10))
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+	if reused == reopened {
+		t.Fatal("expected a new connection for a reused fd number")
+	}
+	other, err := m.Conn(NetNSForFD(12))
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+	if other != reused {
+		t.Fatal("expected the same connection for the same namespace")
+	}
+
+	for _, name := range []string{"", "../x", "rtnetlink-does-not-exist"} {
+		if _, err := m.ConnByName(name); err == nil {
+			t.Fatalf("expected an error for namespace name %q", name)
+		}
+	}
+}
+
+func TestConnListen(t *testing.T) {
+	tc := &testGroupConn{}
+	c := newConn(tc)
+
+	groups := []uint32{GroupLink, GroupIPv4Address, GroupIPv6Route}
+	if err := c.Listen(groups...); err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	if want, got := groups, tc.joined; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected joined groups, want: %v, got: %v", want, got)
+	}
+
+	tc = &testGroupConn{fail: GroupNeigh}
+	c = newConn(tc)
+
+	if err := c.Listen(GroupLink, GroupIPv4Route, GroupNeigh); !errors.Is(err, unix.EPERM) {
+		t.Fatalf("expected EPERM, got: %v", err)
+	}
+	if want, got := []uint32{GroupLink, GroupIPv4Route}, tc.left; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected left groups, want: %v, got: %v", want, got)
+	}
+}
+
+func TestConnReceiveNotification(t *testing.T) {
+	skipBigEndian(t)
+
+	c, tc := testConn(t)
+	tc.receive = []netlink.Message{{
+		Header: netlink.Header{
+			Type: unix.RTM_NEWLINK,
+		},
+		Data: mustMarshal(&LinkMessage{
+			Index: 2,
+			Flags: unix.IFF_UP,
+		}),
+	}}
+
+	msgs, _, err := c.Receive()
+	if err != nil {
+		t.Fatalf("failed to receive: %v", err)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("unexpected number of messages: %d", len(msgs))
+	}
+
+	link, ok := msgs[0].(*LinkMessage)
+	if !ok {
+		t.Fatalf("unexpected message type %T", msgs[0])
+	}
+	if link.Index != 2 || link.Flags != unix.IFF_UP {
+		t.Fatalf("unexpected link message: %#v", link)
+	}
+}
+
+func TestRouteServiceListFunc(t *testing.T) {
+	skipBigEndian(t)
+
+	c, tc := testConn(t)
+	for i := 0; i < 1000; i++ {
+		tc.receive = append(tc.receive, netlink.Message{
+			Header: netlink.Header{Type: unix.RTM_NEWROUTE},
+			Data: mustMarshal(&RouteMessage{
+				Family: unix.AF_INET,
+				Attributes: RouteAttributes{
+					Table: uint32(i),
+				},
+			}),
+		})
+	}
+
+	var tables []uint32
+	err := c.Route.ListFunc(func(m *RouteMessage) error {
+		tables = append(tables, m.Attributes.Table)
+		if len(tables) == 10 {
+			return ErrStopList
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to list routes: %v", err)
+	}
+
+	want := []uint32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if !reflect.DeepEqual(want, tables) {
+		t.Fatalf("unexpected route tables, want: %v, got: %v", want, tables)
+	}
+	if want, got := netlink.HeaderType(unix.RTM_GETROUTE), tc.send.Header.Type; want != got {
+		t.Fatalf("unexpected request type, want: %v, got: %v", want, got)
+	}
+
+	errTest := errors.New("test error")
+	var n int
+	err = c.Route.ListFunc(func(m *RouteMessage) error {
+		n++
+		return errTest
+	})
+	if !errors.Is(err, errTest) {
+		t.Fatalf("expected callback error, got: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("callback was called %d times after returning an error", n)
+	}
+}
+
+func TestLinkServiceListFunc(t *testing.T) {
+	skipBigEndian(t)
+
+	c, tc := testConn(t)
+	for i := 1; i <= 1000; i++ {
+		tc.receive = append(tc.receive, netlink.Message{
+			Header: netlink.Header{Type: unix.RTM_NEWLINK},
+			Data:   mustMarshal(&LinkMessage{Index: uint32(i)}),
+		})
+	}
+
+	var n int
+	err := c.Link.ListFunc(func(m *LinkMessage) error {
+		n++
+		if m.Index == 5 {
+			return ErrStopList
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to list links: %v", err)
+	}
+	if n != 5 {
+		t.Fatalf("unexpected number of callbacks, want: 5, got: %d", n)
+	}
+
+	n = 0
+	if err := c.Link.ListFunc(func(m *LinkMessage) error {
+		n++
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to list links: %v", err)
+	}
+	if n != 1000 {
+		t.Fatalf("unexpected number of callbacks, want: 1000, got: %d", n)
+	}
+}
+
+func TestConnExecuteContext(t *testing.T) {
+	t.Run("cancel", func(t *testing.T) {
+		tc := newTestDeadlineConn()
+		c := newConn(tc)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errC := make(chan error, 1)
+		go func() {
+			_, err := c.ExecuteContext(ctx, &LinkMessage{}, unix.RTM_GETLINK, netlink.Request)
+			errC <- err
+		}()
+
+		<-tc.executing
+		cancel()
+
+		select {
+		case err := <-errC:
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected context.Canceled, got: %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("request was not aborted after the context was cancelled")
+		}
+
+		if !tc.readDeadline().IsZero() {
+			t.Fatalf("read deadline was not cleared: %v", tc.readDeadline())
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		tc := newTestDeadlineConn()
+		c := newConn(tc)
+
+		deadline := time.Now().Add(-time.Second)
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+
+		_, err := c.ExecuteContext(ctx, &LinkMessage{}, unix.RTM_GETLINK, netlink.Request)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+		}
+	})
+
+	t.Run("route list", func(t *testing.T) {
+		tc := newTestDeadlineConn()
+		c := newConn(tc)
+
+		deadline := time.Now().Add(time.Hour)
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+
+		errC := make(chan error, 1)
+		go func() {
+			_, err := c.Route.ListContext(ctx)
+			errC <- err
+		}()
+
+		<-tc.executing
+		if want, got := deadline, tc.readDeadline(); !want.Equal(got) {
+			t.Fatalf("unexpected read deadline, want: %v, got: %v", want, got)
+		}
+		cancel()
+
+		if err := <-errC; !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got: %v", err)
+		}
+	})
+}
+
 func testConn(t *testing.T) (*Conn, *testNetlinkConn) {
 	c := &testNetlinkConn{}
 	return newConn(c), c
@@ -206,14 +1148,155 @@ func (c *testNetlinkConn) Execute(m netlink.Message) ([]netlink.Message, error)
 	return c.receive, nil
 }
 
+type testBatchConn struct {
+	sent    []netlink.Message
+	receive []error
+	acked   int
+
+	// notify holds unrelated messages, such as multicast notifications,
+	// which are received before the replies.
+	notify []netlink.Message
+
+	noopConn
+}
+
+func (c *testBatchConn) SendMessages(ms []netlink.Message) ([]netlink.Message, error) {
+	for i := range ms {
+		ms[i].Header.Sequence = uint32(len(c.sent) + 1)
+		c.sent = append(c.sent, ms[i])
+	}
+	return ms, nil
+}
+
+func (c *testBatchConn) Receive() ([]netlink.Message, error) {
+	if len(c.notify) > 0 {
+		m := c.notify[0]
+		c.notify = c.notify[1:]
+		return []netlink.Message{m}, nil
+	}
+
+	err := c.receive[0]
+	c.receive = c.receive[1:]
+	seq := c.sent[c.acked].Header.Sequence
+	c.acked++
+	if err != nil {
+		return nil, err
+	}
+
+	return []netlink.Message{{
+		Header: netlink.Header{Type: netlink.Error, Sequence: seq},
+		Data:   make([]byte, 4),
+	}}, nil
+}
+
+type testNameConn struct {
+	sent  []netlink.Message
+	errs  []error
+	reply netlink.Message
+
+	noopConn
+}
+
+func (c *testNameConn) Execute(m netlink.Message) ([]netlink.Message, error) {
+	c.sent = append(c.sent, m)
+	if err := c.errs[len(c.sent)-1]; err != nil {
+		return nil, err
+	}
+	return []netlink.Message{c.reply}, nil
+}
+
+type testGroupConn struct {
+	joined, left []uint32
+	fail         uint32 // group that cannot be joined, if non-zero
+
+	noopConn
+}
+
+func (c *testGroupConn) JoinGroup(group uint32) error {
+	if group == c.fail {
+		return unix.EPERM
+	}
+	c.joined = append(c.joined, group)
+	return nil
+}
+
+func (c *testGroupConn) LeaveGroup(group uint32) error {
+	c.left = append(c.left, group)
+	return nil
+}
+
+// testDeadlineConn blocks in Execute until its read deadline is moved into the
+// past, like a netlink socket waiting for a reply that never arrives.
+type testDeadlineConn struct {
+	mu        sync.Mutex
+	deadline  time.Time
+	changed   chan struct{}
+	executing chan struct{}
+
+	noopConn
+}
+
+func newTestDeadlineConn() *testDeadlineConn {
+	return &testDeadlineConn{
+		changed:   make(chan struct{}, 1),
+		executing: make(chan struct{}),
+	}
+}
+
+func (c *testDeadlineConn) Execute(_ netlink.Message) ([]netlink.Message, error) {
+	close(c.executing)
+	for {
+		d := c.readDeadline()
+		if !d.IsZero() && !d.After(time.Now()) {
+			return nil, os.ErrDeadlineExceeded
+		}
+		<-c.changed
+	}
+}
+
+func (c *testDeadlineConn) SetReadDeadline(t time.Time) error {
+	c.mu.Lock()
+	c.deadline = t
+	c.mu.Unlock()
+
+	select {
+	case c.changed <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (c *testDeadlineConn) readDeadline() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.deadline
+}
+
+type testCloseConn struct {
+	closed bool
+
+	noopConn
+}
+
+func (c *testCloseConn) Close() error {
+	c.closed = true
+	return nil
+}
+
 type noopConn struct{}
 
-func (c *noopConn) Close() error                                         { return nil }
-func (c *noopConn) Send(_ netlink.Message) (netlink.Message, error)      { return netlink.Message{}, nil }
+func (c *noopConn) Close() error                                    { return nil }
+func (c *noopConn) Send(_ netlink.Message) (netlink.Message, error) { return netlink.Message{}, nil }
+func (c *noopConn) SendMessages(_ []netlink.Message) ([]netlink.Message, error) {
+	return nil, nil
+}
 func (c *noopConn) Receive() ([]netlink.Message, error)                  { return nil, nil }
 func (c *noopConn) Execute(m netlink.Message) ([]netlink.Message, error) { return nil, nil }
+func (c *noopConn) JoinGroup(_ uint32) error                             { return nil }
+func (c *noopConn) LeaveGroup(_ uint32) error                            { return nil }
 func (c *noopConn) SetOption(_ netlink.ConnOption, _ bool) error         { return nil }
 func (c *noopConn) SetReadDeadline(t time.Time) error                    { return nil }
+func (c *noopConn) SetWriteDeadline(t time.Time) error                   { return nil }
 
 func mustMarshal(m encoding.BinaryMarshaler) []byte {
 	b, err := m.MarshalBinary()
@@ -0,0 +1,92 @@
+package driver
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+const (
+	eth_p_ip      = 0x0800
+	eth_p_mpls_uc = 0x8847
+)
+
+// Bareudp implements LinkDriverVerifier for the bareudp driver
+type Bareudp struct {
+	// Specifies the UDP destination port of the tunnel, required
+	Port *uint16
+
+	// Specifies the ethertype of the tunneled protocol, required
+	EtherType *uint16
+
+	// Specifies the lowest UDP source port of sent packets
+	SrcPortMin *uint16
+
+	// Specifies whether related protocols are tunneled as well, IPv6 for
+	// IPv4 and multicast MPLS for unicast MPLS
+	MultiProto bool
+}
+
+var _ rtnetlink.LinkDriverVerifier = &Bareudp{}
+
+func (b *Bareudp) New() rtnetlink.LinkDriver {
+	return &Bareudp{}
+}
+
+func (b *Bareudp) Verify(msg *rtnetlink.LinkMessage) error {
+	if b.Port == nil || b.EtherType == nil {
+		return errors.New("bareudp requires a port and an ethertype")
+	}
+	if b.MultiProto && *b.EtherType != eth_p_ip && *b.EtherType != eth_p_mpls_uc {
+		return fmt.Errorf("bareudp multiproto mode is not supported for ethertype %#04x", *b.EtherType)
+	}
+	return nil
+}
+
+func (b *Bareudp) Encode(ae *netlink.AttributeEncoder) error {
+	if b.Port != nil {
+		encodeBE16(ae, unix.IFLA_BAREUDP_PORT, *b.Port)
+	}
+	if b.EtherType != nil {
+		encodeBE16(ae, unix.IFLA_BAREUDP_ETHERTYPE, *b.EtherType)
+	}
+	if b.SrcPortMin != nil {
+		ae.Uint16(unix.IFLA_BAREUDP_SRCPORT_MIN, *b.SrcPortMin)
+	}
+	if b.MultiProto {
+		ae.Flag(unix.IFLA_BAREUDP_MULTIPROTO_MODE, true)
+	}
+	return nil
+}
+
+func (b *Bareudp) Decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_BAREUDP_PORT:
+			v, err := decodeBE16(ad)
+			if err != nil {
+				return err
+			}
+			b.Port = &v
+		case unix.IFLA_BAREUDP_ETHERTYPE:
+			v, err := decodeBE16(ad)
+			if err != nil {
+				return err
+			}
+			b.EtherType = &v
+		case unix.IFLA_BAREUDP_SRCPORT_MIN:
+			v := ad.Uint16()
+			b.SrcPortMin = &v
+		case unix.IFLA_BAREUDP_MULTIPROTO_MODE:
+			b.MultiProto = true
+		}
+	}
+	return nil
+}
+
+func (*Bareudp) Kind() string {
+	return "bareudp"
+}
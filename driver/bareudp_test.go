@@ -0,0 +1,118 @@
+package driver
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/mdlayher/netlink"
+)
+
+func TestBareudpRoundTrip(t *testing.T) {
+	var (
+		port    uint16 = 6635
+		mpls    uint16 = eth_p_mpls_uc
+		srcPort uint16 = 49152
+	)
+
+	tests := []struct {
+		name    string
+		bareudp *Bareudp
+	}{
+		{
+			name:    "empty",
+			bareudp: &Bareudp{},
+		},
+		{
+			name: "full",
+			bareudp: &Bareudp{
+				Port:       &port,
+				EtherType:  &mpls,
+				SrcPortMin: &srcPort,
+				MultiProto: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundTrip(tt.bareudp)
+			if err != nil {
+				t.Fatalf("failed to round trip bareudp: %v", err)
+			}
+
+			if diff := cmp.Diff(rtnetlink.LinkDriver(tt.bareudp), got); diff != "" {
+				t.Fatalf("unexpected bareudp (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBareudpEncode(t *testing.T) {
+	var (
+		port uint16 = 6635
+		ip   uint16 = eth_p_ip
+	)
+
+	ae := netlink.NewAttributeEncoder()
+	if err := (&Bareudp{Port: &port, EtherType: &ip}).Encode(ae); err != nil {
+		t.Fatalf("failed to encode bareudp: %v", err)
+	}
+	b, err := ae.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode attributes: %v", err)
+	}
+
+	want := []byte{
+		0x06, 0x00, 0x01, 0x00, 0x19, 0xeb, 0x00, 0x00,
+		0x06, 0x00, 0x02, 0x00, 0x08, 0x00, 0x00, 0x00,
+	}
+	if !bytes.Equal(want, b) {
+		t.Fatalf("unexpected bytes:\n- want: [%# x]\n-  got: [%# x]", want, b)
+	}
+}
+
+func TestBareudpVerify(t *testing.T) {
+	var (
+		port uint16 = 6635
+		ip   uint16 = eth_p_ip
+		ipv6 uint16 = 0x86dd
+	)
+
+	tests := []struct {
+		name    string
+		bareudp *Bareudp
+		ok      bool
+	}{
+		{
+			name:    "missing port",
+			bareudp: &Bareudp{EtherType: &ip},
+		},
+		{
+			name:    "missing ethertype",
+			bareudp: &Bareudp{Port: &port},
+		},
+		{
+			name:    "IPv4 multiproto",
+			bareudp: &Bareudp{Port: &port, EtherType: &ip, MultiProto: true},
+			ok:      true,
+		},
+		{
+			name:    "IPv6 multiproto",
+			bareudp: &Bareudp{Port: &port, EtherType: &ipv6, MultiProto: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bareudp.Verify(&rtnetlink.LinkMessage{})
+			if tt.ok && err != nil {
+				t.Fatalf("failed to verify bareudp: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
@@ -402,7 +402,8 @@ type Bond struct {
 	// Specifies the number of IGMP membership reports to be issued after a failover event
 	ResendIgmp *uint32
 
-	// Specify the number of peer notifications (gratuitous ARPs and unsolicited IPv6 Neighbor Advertisements) to be issued after a failover event
+	// Specify the number of peer notifications (gratuitous ARPs and unsolicited IPv6 Neighbor Advertisements) to be issued after a failover event.
+	// The num_grat_arp and num_unsol_na options are aliases of this attribute, the kernel has no separate attributes for them
 	NumPeerNotif *uint8
 
 	// Specifies that duplicate frames (received on inactive ports) should be dropped (0) or delivered (1)
@@ -426,31 +427,76 @@ type Bond struct {
 	// Specifies the 802.3ad aggregation selection logic to use
 	AdSelect *BondAdSelect
 
-	// In an AD system, this specifies the system priority
+	// In an AD system, this specifies the system priority, only decoded in 802.3ad mode
 	AdActorSysPrio *uint16
 
-	// Defines the upper 10 bits of the port key
+	// Defines the upper 10 bits of the port key, only decoded in 802.3ad mode
 	AdUserPortKey *uint16
 
-	// In an AD system, this specifies the mac-address for the actor in protocol packet exchanges
+	// In an AD system, this specifies the mac-address for the actor in protocol packet exchanges.
+	// Together with AdActorSysPrio it forms the system identifier, it must be a 6 byte unicast address.
+	// Only decoded in 802.3ad mode
 	AdActorSystem net.HardwareAddr
 
-	// Specifies if dynamic shuffling of flows is enabled in tlb or alb mode
+	// Specifies if dynamic shuffling of flows is enabled in tlb or alb mode, the kernel default is 1
 	TlbDynamicLb *uint8
 
 	// Specifies the number of arp_interval monitor checks that must fail in order for an interface to be marked down by the ARP monitor
 	MissedMax *uint8
 
-	// Specifies the 802.3ad aggregation information, this is read only value
+	// Specifies the 802.3ad aggregation information, this is read only value.
+	// It is only populated for bonds in 802.3ad mode and nil otherwise, use
+	// AggregationInfo for nil-safe access.
 	AdInfo *BondAdInfo
 }
 
 var _ rtnetlink.LinkDriver = &Bond{}
 
+var _ rtnetlink.LinkDriverVerifier = &Bond{}
+
 func (b *Bond) New() rtnetlink.LinkDriver {
 	return &Bond{}
 }
 
+// Is8023ad reports whether the bond uses the 802.3ad (LACP) mode.
+func (b *Bond) Is8023ad() bool {
+	return b.Mode == BondMode802_3AD
+}
+
+// AggregationInfo returns the 802.3ad aggregation information of the bond.
+// The boolean is false when the information was not reported, which is the
+// case for bonds in any mode other than 802.3ad.
+func (b *Bond) AggregationInfo() (BondAdInfo, bool) {
+	if b.AdInfo == nil {
+		return BondAdInfo{}, false
+	}
+	return *b.AdInfo, true
+}
+
+// Verify checks that options which are only supported in 802.3ad mode are
+// not set for other modes, as the kernel rejects those with EINVAL.
+// MinLinks and AdSelect are accepted and reported by the kernel in every mode.
+// Decode drops the 802.3ad only options of other modes, so a decoded bond
+// passes Verify.
+func (b *Bond) Verify(msg *rtnetlink.LinkMessage) error {
+	if b.Is8023ad() {
+		return nil
+	}
+	for _, opt := range []struct {
+		name string
+		set  bool
+	}{
+		{"AdActorSysPrio", b.AdActorSysPrio != nil},
+		{"AdUserPortKey", b.AdUserPortKey != nil},
+		{"AdActorSystem", b.AdActorSystem != nil},
+	} {
+		if opt.set {
+			return fmt.Errorf("bond option %s is only supported in %s mode, got mode %s", opt.name, BondMode802_3AD, b.Mode)
+		}
+	}
+	return nil
+}
+
 func (b *Bond) Encode(ae *netlink.AttributeEncoder) error {
 	if b.Mode < BondModeUnknown {
 		ae.Uint8(unix.IFLA_BOND_MODE, uint8(b.Mode))
@@ -484,7 +530,7 @@ func (b *Bond) Encode(ae *netlink.AttributeEncoder) error {
 			for i := range b.ArpIpTargets {
 				ip := b.ArpIpTargets[i].To4()
 				if ip == nil {
-					return fmt.Errorf("%s is not an ip4 address", ip)
+					return fmt.Errorf("%s is not an ip4 address", b.ArpIpTargets[i])
 				}
 				nae.Bytes(uint16(i), ip)
 			}
@@ -492,14 +538,14 @@ func (b *Bond) Encode(ae *netlink.AttributeEncoder) error {
 		})
 	}
 	if b.NsIP6Targets != nil {
-		if lb := len(b.ArpIpTargets); lb > bondMaxTargets {
+		if lb := len(b.NsIP6Targets); lb > bondMaxTargets {
 			return fmt.Errorf("exceeded max NsIP6Targets %d, %d", bondMaxTargets, lb)
 		}
 		ae.Nested(unix.IFLA_BOND_NS_IP6_TARGET, func(nae *netlink.AttributeEncoder) error {
 			for i := range b.NsIP6Targets {
 				ip := b.NsIP6Targets[i].To16()
 				if ip == nil {
-					return fmt.Errorf("%s is not an ip6 address", ip)
+					return fmt.Errorf("%s is not an ip6 address", b.NsIP6Targets[i])
 				}
 				nae.Bytes(uint16(i), ip)
 			}
@@ -558,6 +604,9 @@ func (b *Bond) Encode(ae *netlink.AttributeEncoder) error {
 		ae.Uint16(unix.IFLA_BOND_AD_USER_PORT_KEY, *b.AdUserPortKey)
 	}
 	if b.AdActorSystem != nil {
+		if l := len(b.AdActorSystem); l != 6 {
+			return fmt.Errorf("invalid AdActorSystem length %d, must be a 6 byte mac address", l)
+		}
 		ae.Bytes(unix.IFLA_BOND_AD_ACTOR_SYSTEM, []byte(b.AdActorSystem))
 	}
 	if b.TlbDynamicLb != nil {
@@ -599,14 +648,22 @@ func (b *Bond) Decode(ad *netlink.AttributeDecoder) error {
 		case unix.IFLA_BOND_ARP_IP_TARGET:
 			ad.Nested(func(nad *netlink.AttributeDecoder) error {
 				for nad.Next() {
-					b.ArpIpTargets = append(b.ArpIpTargets, nad.Bytes())
+					ip := net.IP(nad.Bytes()).To4()
+					if ip == nil {
+						return fmt.Errorf("invalid ArpIpTargets length %d", len(nad.Bytes()))
+					}
+					b.ArpIpTargets = append(b.ArpIpTargets, ip)
 				}
 				return nil
 			})
 		case unix.IFLA_BOND_NS_IP6_TARGET:
 			ad.Nested(func(nad *netlink.AttributeDecoder) error {
 				for nad.Next() {
-					b.NsIP6Targets = append(b.NsIP6Targets, nad.Bytes())
+					ip := nad.Bytes()
+					if len(ip) != net.IPv6len {
+						return fmt.Errorf("invalid NsIP6Targets length %d", len(ip))
+					}
+					b.NsIP6Targets = append(b.NsIP6Targets, ip)
 				}
 				return nil
 			})
@@ -690,7 +747,15 @@ func (b *Bond) Decode(ad *netlink.AttributeDecoder) error {
 			})
 		}
 	}
-	return nil
+	if !b.Is8023ad() {
+		// The kernel reports these options in every mode, but only
+		// accepts them in 802.3ad mode. Drop them so a decoded bond can
+		// be sent back.
+		b.AdActorSysPrio = nil
+		b.AdUserPortKey = nil
+		b.AdActorSystem = nil
+	}
+	return ad.Err()
 }
 
 func (*Bond) Kind() string {
@@ -12,6 +12,7 @@ import (
 	"github.com/jsimonetti/rtnetlink/v2"
 	"github.com/jsimonetti/rtnetlink/v2/internal/testutils"
 	"github.com/mdlayher/netlink"
+	"golang.org/x/sys/unix"
 )
 
 func bondT(d rtnetlink.LinkDriver) *Bond {
@@ -170,13 +171,13 @@ func TestBond(t *testing.T) {
 			}
 
 			slave1ID := 1101 + uint32(i*10)
-			if err := setupInterface(tt.conn, fmt.Sprintf("d%d", slave1ID), slave1ID, bondID, &rtnetlink.LinkData{Name: "dummy"}); err != nil {
+			if err := setupInterface(tt.conn, fmt.Sprintf("d%d", slave1ID), slave1ID, bondID, &Dummy{}); err != nil {
 				t.Fatalf("failed to setup d%d interface: %v", slave1ID, err)
 			}
 			defer tt.conn.Link.Delete(slave1ID)
 
 			slave2ID := 1102 + uint32(i*10)
-			if err := setupInterface(tt.conn, fmt.Sprintf("d%d", slave2ID), slave2ID, bondID, &rtnetlink.LinkData{Name: "dummy"}); err != nil {
+			if err := setupInterface(tt.conn, fmt.Sprintf("d%d", slave2ID), slave2ID, bondID, &Dummy{}); err != nil {
 				t.Fatalf("failed to setup d1%d interface: %v", slave2ID, err)
 			}
 			defer tt.conn.Link.Delete(slave2ID)
@@ -207,3 +208,52 @@ func TestBond(t *testing.T) {
 		})
 	}
 }
+
+func TestBondEnslave(t *testing.T) {
+	testutils.SkipOnOldKernel(t, "6.0", "bond slave priority support")
+
+	conn, err := rtnetlink.Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket to netns: %v", err)
+	}
+	defer conn.Close()
+
+	const (
+		bondID  = 1200
+		dummyID = 1201
+	)
+
+	if err := setupInterface(conn, "b1200", bondID, 0, &Bond{Mode: BondModeActiveBackup}); err != nil {
+		t.Fatalf("failed to setup bond interface: %v", err)
+	}
+	defer conn.Link.Delete(bondID)
+
+	if err := setupInterface(conn, "d1201", dummyID, 0, &Dummy{}); err != nil {
+		t.Fatalf("failed to setup dummy interface: %v", err)
+	}
+	defer conn.Link.Delete(dummyID)
+
+	// Bring the dummy down, as a bond only accepts slaves which are down.
+	if err := conn.Link.Set(&rtnetlink.LinkMessage{
+		Index:  dummyID,
+		Change: unix.IFF_UP,
+	}); err != nil {
+		t.Fatalf("failed to set dummy interface down: %v", err)
+	}
+
+	prio := int32(5)
+	if err := conn.Link.Enslave(dummyID, bondID, &BondSlave{Priority: &prio}); err != nil {
+		t.Fatalf("failed to enslave dummy interface: %v", err)
+	}
+
+	msg, err := getInterface(conn, dummyID)
+	if err != nil {
+		t.Fatalf("failed to get dummy interface: %v", err)
+	}
+	if msg.Attributes.Master == nil || *msg.Attributes.Master != bondID {
+		t.Fatalf("dummy interface is not enslaved to bond %d", bondID)
+	}
+	if diff := cmp.Diff(&prio, bondSlaveT(msg.Attributes.Info.SlaveData).Priority); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,391 @@
+package driver
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+func TestBondVerify(t *testing.T) {
+	var (
+		u16    uint16 = 100
+		u32    uint32 = 2
+		stable        = BondAdSelectStable
+	)
+
+	tests := []struct {
+		name string
+		bond *Bond
+		ok   bool
+	}{
+		{
+			name: "802.3ad",
+			bond: &Bond{
+				Mode:           BondMode802_3AD,
+				MinLinks:       &u32,
+				AdSelect:       &stable,
+				AdActorSysPrio: &u16,
+				AdUserPortKey:  &u16,
+			},
+			ok: true,
+		},
+		{
+			name: "balance-rr without 802.3ad options",
+			bond: &Bond{
+				Mode: BondModeBalanceRR,
+			},
+			ok: true,
+		},
+		{
+			name: "balance-rr with AdActorSysPrio",
+			bond: &Bond{
+				Mode:           BondModeBalanceRR,
+				AdActorSysPrio: &u16,
+			},
+		},
+		{
+			name: "balance-rr with AdActorSystem",
+			bond: &Bond{
+				Mode:          BondModeBalanceRR,
+				AdActorSystem: net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			},
+		},
+		{
+			name: "active-backup with MinLinks and AdSelect",
+			bond: &Bond{
+				Mode:     BondModeActiveBackup,
+				MinLinks: &u32,
+				AdSelect: &stable,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &rtnetlink.LinkMessage{
+				Attributes: &rtnetlink.LinkAttributes{
+					Info: &rtnetlink.LinkInfo{
+						Kind: tt.bond.Kind(),
+						Data: tt.bond,
+					},
+				},
+			}
+
+			_, err := msg.MarshalBinary()
+			if tt.ok && err != nil {
+				t.Fatalf("failed to marshal bond: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
+
+func TestBondDecodedSet(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   BondMode
+		keepAd bool
+	}{
+		{
+			name: "balance-rr",
+			mode: BondModeBalanceRR,
+		},
+		{
+			name: "active-backup",
+			mode: BondModeActiveBackup,
+		},
+		{
+			name:   "802.3ad",
+			mode:   BondMode802_3AD,
+			keepAd: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The attributes the kernel reports for a bond, which include
+			// options that only take effect in other modes.
+			ae := netlink.NewAttributeEncoder()
+			ae.Uint8(unix.IFLA_BOND_MODE, uint8(tt.mode))
+			ae.Uint32(unix.IFLA_BOND_MIIMON, 100)
+			ae.Uint32(unix.IFLA_BOND_MIN_LINKS, 0)
+			ae.Uint8(unix.IFLA_BOND_AD_SELECT, uint8(BondAdSelectStable))
+			ae.Uint16(unix.IFLA_BOND_AD_ACTOR_SYS_PRIO, 65535)
+			ae.Uint16(unix.IFLA_BOND_AD_USER_PORT_KEY, 0)
+			ae.Bytes(unix.IFLA_BOND_AD_ACTOR_SYSTEM, make([]byte, 6))
+			ae.Uint8(unix.IFLA_BOND_TLB_DYNAMIC_LB, 1)
+			ae.Uint8(unix.IFLA_BOND_NUM_PEER_NOTIF, 1)
+			b, err := ae.Encode()
+			if err != nil {
+				t.Fatalf("failed to encode attributes: %v", err)
+			}
+
+			ad, err := netlink.NewAttributeDecoder(b)
+			if err != nil {
+				t.Fatalf("failed to create attribute decoder: %v", err)
+			}
+			bond := &Bond{}
+			if err := bond.Decode(ad); err != nil {
+				t.Fatalf("failed to decode bond: %v", err)
+			}
+
+			if want, got := tt.keepAd, bond.AdActorSysPrio != nil; want != got {
+				t.Fatalf("unexpected AdActorSysPrio presence, want: %v, got: %v", want, got)
+			}
+			if want, got := tt.keepAd, bond.AdUserPortKey != nil; want != got {
+				t.Fatalf("unexpected AdUserPortKey presence, want: %v, got: %v", want, got)
+			}
+			if want, got := tt.keepAd, bond.AdActorSystem != nil; want != got {
+				t.Fatalf("unexpected AdActorSystem presence, want: %v, got: %v", want, got)
+			}
+
+			msg := &rtnetlink.LinkMessage{
+				Attributes: &rtnetlink.LinkAttributes{
+					Info: &rtnetlink.LinkInfo{
+						Kind: bond.Kind(),
+						Data: bond,
+					},
+				},
+			}
+			if _, err := msg.MarshalBinary(); err != nil {
+				t.Fatalf("failed to marshal decoded bond: %v", err)
+			}
+		})
+	}
+}
+
+func TestBondDecodeAdInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   BondMode
+		adInfo bool
+		is8023 bool
+		want   BondAdInfo
+	}{
+		{
+			name: "balance-rr",
+			mode: BondModeBalanceRR,
+		},
+		{
+			name:   "802.3ad",
+			mode:   BondMode802_3AD,
+			adInfo: true,
+			is8023: true,
+			want: BondAdInfo{
+				AggregatorId: 1,
+				NumPorts:     2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ae := netlink.NewAttributeEncoder()
+			ae.Uint8(unix.IFLA_BOND_MODE, uint8(tt.mode))
+			if tt.adInfo {
+				ae.Nested(unix.IFLA_BOND_AD_INFO, func(nae *netlink.AttributeEncoder) error {
+					nae.Uint16(unix.IFLA_BOND_AD_INFO_AGGREGATOR, tt.want.AggregatorId)
+					nae.Uint16(unix.IFLA_BOND_AD_INFO_NUM_PORTS, tt.want.NumPorts)
+					return nil
+				})
+			}
+			b, err := ae.Encode()
+			if err != nil {
+				t.Fatalf("failed to encode attributes: %v", err)
+			}
+			ad, err := netlink.NewAttributeDecoder(b)
+			if err != nil {
+				t.Fatalf("failed to create attribute decoder: %v", err)
+			}
+
+			bond := &Bond{}
+			if err := bond.Decode(ad); err != nil {
+				t.Fatalf("failed to decode bond: %v", err)
+			}
+
+			if want, got := tt.is8023, bond.Is8023ad(); want != got {
+				t.Fatalf("unexpected Is8023ad, want: %v, got: %v", want, got)
+			}
+			if want, got := tt.adInfo, bond.AdInfo != nil; want != got {
+				t.Fatalf("unexpected AdInfo presence, want: %v, got: %v", want, got)
+			}
+
+			info, ok := bond.AggregationInfo()
+			if want, got := tt.adInfo, ok; want != got {
+				t.Fatalf("unexpected AggregationInfo presence, want: %v, got: %v", want, got)
+			}
+			if diff := cmp.Diff(tt.want, info); diff != "" {
+				t.Fatalf("unexpected AggregationInfo (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBondEncodeMaxTargets(t *testing.T) {
+	targets := func(n int, ip net.IP) []net.IP {
+		ips := make([]net.IP, n)
+		for i := range ips {
+			ips[i] = ip
+		}
+		return ips
+	}
+
+	tests := []struct {
+		name string
+		bond *Bond
+		ok   bool
+	}{
+		{
+			name: "max ArpIpTargets",
+			bond: &Bond{ArpIpTargets: targets(bondMaxTargets, net.IPv4(192, 0, 2, 1))},
+			ok:   true,
+		},
+		{
+			name: "too many ArpIpTargets",
+			bond: &Bond{ArpIpTargets: targets(bondMaxTargets+1, net.IPv4(192, 0, 2, 1))},
+		},
+		{
+			name: "max NsIP6Targets",
+			bond: &Bond{NsIP6Targets: targets(bondMaxTargets, net.ParseIP("2001:db8::1"))},
+			ok:   true,
+		},
+		{
+			name: "too many NsIP6Targets",
+			bond: &Bond{NsIP6Targets: targets(bondMaxTargets+1, net.ParseIP("2001:db8::1"))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bond.Encode(netlink.NewAttributeEncoder())
+			if tt.ok && err != nil {
+				t.Fatalf("failed to encode bond: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
+
+func TestBondEncodeAdActorSystem(t *testing.T) {
+	tests := []struct {
+		name string
+		mac  net.HardwareAddr
+		ok   bool
+	}{
+		{
+			name: "valid",
+			mac:  net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			ok:   true,
+		},
+		{
+			name: "short",
+			mac:  net.HardwareAddr{0x02, 0x00, 0x00},
+		},
+		{
+			name: "EUI-64",
+			mac:  net.HardwareAddr{0x02, 0x00, 0x00, 0xff, 0xfe, 0x00, 0x00, 0x01},
+		},
+		{
+			name: "empty",
+			mac:  net.HardwareAddr{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bond := &Bond{Mode: BondMode802_3AD, AdActorSystem: tt.mac}
+			err := bond.Encode(netlink.NewAttributeEncoder())
+			if tt.ok && err != nil {
+				t.Fatalf("failed to encode bond: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
+
+func TestBondRoundTripMaxTargets(t *testing.T) {
+	bond := &Bond{Mode: BondModeActiveBackup}
+	var want []net.IP
+	for i := 0; i < bondMaxTargets; i++ {
+		// 16 byte IPv4 addresses are decoded as 4 byte addresses.
+		bond.ArpIpTargets = append(bond.ArpIpTargets, net.IPv4(192, 0, 2, byte(i+1)))
+		want = append(want, net.IPv4(192, 0, 2, byte(i+1)).To4())
+
+		bond.NsIP6Targets = append(bond.NsIP6Targets, net.ParseIP(fmt.Sprintf("2001:db8::%x", i+1)))
+	}
+
+	got, err := RoundTrip(bond)
+	if err != nil {
+		t.Fatalf("failed to round trip bond: %v", err)
+	}
+
+	b := got.(*Bond)
+	if diff := cmp.Diff(want, b.ArpIpTargets); diff != "" {
+		t.Fatalf("unexpected ArpIpTargets (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(bond.NsIP6Targets, b.NsIP6Targets); diff != "" {
+		t.Fatalf("unexpected NsIP6Targets (-want +got):\n%s", diff)
+	}
+}
+
+func TestBondDecodeInvalidTargets(t *testing.T) {
+	ae := netlink.NewAttributeEncoder()
+	ae.Nested(unix.IFLA_BOND_ARP_IP_TARGET, func(nae *netlink.AttributeEncoder) error {
+		nae.Bytes(0, []byte{192, 0, 2})
+		return nil
+	})
+	b, err := ae.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode attributes: %v", err)
+	}
+
+	ad, err := netlink.NewAttributeDecoder(b)
+	if err != nil {
+		t.Fatalf("failed to create attribute decoder: %v", err)
+	}
+
+	if err := (&Bond{}).Decode(ad); err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+}
+
+func TestBondNumPeerNotif(t *testing.T) {
+	n := uint8(3)
+	got, err := RoundTrip(&Bond{Mode: BondModeActiveBackup, NumPeerNotif: &n})
+	if err != nil {
+		t.Fatalf("failed to round trip bond: %v", err)
+	}
+	if diff := cmp.Diff(&n, got.(*Bond).NumPeerNotif); diff != "" {
+		t.Fatalf("unexpected NumPeerNotif (-want +got):\n%s", diff)
+	}
+
+	ae := netlink.NewAttributeEncoder()
+	if err := (&Bond{Mode: BondModeActiveBackup}).Encode(ae); err != nil {
+		t.Fatalf("failed to encode bond: %v", err)
+	}
+	b, err := ae.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode attributes: %v", err)
+	}
+	ad, err := netlink.NewAttributeDecoder(b)
+	if err != nil {
+		t.Fatalf("failed to create attribute decoder: %v", err)
+	}
+	for ad.Next() {
+		if ad.Type() == unix.IFLA_BOND_NUM_PEER_NOTIF {
+			t.Fatal("unset NumPeerNotif should not be encoded")
+		}
+	}
+}
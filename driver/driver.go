@@ -3,6 +3,8 @@
 package driver
 
 import (
+	"fmt"
+
 	"github.com/jsimonetti/rtnetlink/v2"
 )
 
@@ -13,11 +15,44 @@ import (
 // If required, we could consider implementing rtnetlink.UnregisterDriver to address this.
 func init() {
 	for _, drv := range []rtnetlink.LinkDriver{
+		&Bareudp{},
 		&Bond{},
 		&BondSlave{},
+		&Dummy{},
+		&Geneve{},
+		&Gre{},
+		&Gretap{},
+		&Gtp{},
+		&Ip6tnl{},
+		&Ipip{},
+		&Ipvlan{},
+		&Macsec{},
 		&Netkit{},
+		&Sit{},
+		&Tuntap{},
 		&Veth{},
+		&Vrf{},
+		&Vti{},
+		&Vti6{},
+		&Xfrm{},
 	} {
 		_ = rtnetlink.RegisterDriver(drv)
 	}
 }
+
+const (
+	eth_min_mtu = 68    // Min IPv4 MTU per RFC791
+	eth_max_mtu = 65535 // 65535, same as IP_MAX_MTU
+)
+
+// verifyMTU checks that the MTU requested by msg, if any, is within the
+// min and max bounds of a driver.
+func verifyMTU(msg *rtnetlink.LinkMessage, min, max uint32) error {
+	if msg.Attributes == nil || msg.Attributes.MTU == 0 {
+		return nil
+	}
+	if msg.Attributes.MTU < min || msg.Attributes.MTU > max {
+		return fmt.Errorf("invalid MTU value %d, must be between %d %d", msg.Attributes.MTU, min, max)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package driver
+
+import (
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/mdlayher/netlink"
+)
+
+// Dummy implements LinkDriver for the dummy driver, which has no driver
+// specific attributes
+type Dummy struct{}
+
+var _ rtnetlink.LinkDriverCapabilities = &Dummy{}
+
+func (d *Dummy) New() rtnetlink.LinkDriver {
+	return &Dummy{}
+}
+
+func (d *Dummy) Encode(ae *netlink.AttributeEncoder) error {
+	return nil
+}
+
+func (d *Dummy) Decode(ad *netlink.AttributeDecoder) error {
+	return nil
+}
+
+func (*Dummy) Kind() string {
+	return "dummy"
+}
+
+// CanBeSlave implements rtnetlink.LinkDriverCapabilities, a dummy link can be
+// enslaved to a bond, bridge or vrf
+func (*Dummy) CanBeSlave() bool {
+	return true
+}
+
+// CanHaveSlaves implements rtnetlink.LinkDriverCapabilities, a dummy link
+// cannot be a master
+func (*Dummy) CanHaveSlaves() bool {
+	return false
+}
@@ -0,0 +1,29 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+)
+
+func TestDummy(t *testing.T) {
+	d := &Dummy{}
+	if got := d.Kind(); got != "dummy" {
+		t.Fatalf("unexpected kind: want %q, got %q", "dummy", got)
+	}
+	if _, ok := d.New().(*Dummy); !ok {
+		t.Fatalf("unexpected driver from New: %T", d.New())
+	}
+	if !d.CanBeSlave() || d.CanHaveSlaves() {
+		t.Fatal("a dummy link must be enslaveable and must not have slaves")
+	}
+
+	got, err := RoundTrip(d)
+	if err != nil {
+		t.Fatalf("failed to round trip dummy: %v", err)
+	}
+	if diff := cmp.Diff(rtnetlink.LinkDriver(d), got); diff != "" {
+		t.Fatalf("unexpected dummy (-want +got):\n%s", diff)
+	}
+}
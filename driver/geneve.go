@@ -0,0 +1,172 @@
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+// GeneveDF specifies how the DF bit of the outer IPv4 header is set
+type GeneveDF uint8
+
+func (g GeneveDF) String() string {
+	switch g {
+	case GeneveDFUnset:
+		return "unset"
+	case GeneveDFSet:
+		return "set"
+	case GeneveDFInherit:
+		return "inherit"
+	default:
+		return fmt.Sprintf("unknown GeneveDF value (%d)", g)
+	}
+}
+
+const (
+	// The DF bit is not set, this is the default
+	GeneveDFUnset GeneveDF = iota
+
+	// The DF bit is always set
+	GeneveDFSet
+
+	// The DF bit is inherited from the encapsulated IPv4 header
+	GeneveDFInherit
+)
+
+// Geneve implements LinkDriverVerifier for the geneve driver
+type Geneve struct {
+	// Specifies the virtual network identifier (VNI) of the tunnel
+	ID *uint32
+
+	// Specifies the remote IPv4 or IPv6 address of the tunnel
+	Remote net.IP
+
+	// Specifies the TTL of sent packets, 0 means the TTL is inherited from the encapsulated packet
+	TTL *uint8
+
+	// Specifies whether the TTL is inherited from the encapsulated packet, conflicts with TTL
+	TTLInherit bool
+
+	// Specifies the TOS of sent packets, 1 means the TOS is inherited from the encapsulated packet
+	TOS *uint8
+
+	// Specifies the UDP destination port of the tunnel
+	Port *uint16
+
+	// Specifies whether the tunnel is flow based, taking its parameters from the packet metadata
+	CollectMetadata bool
+
+	// Specifies whether the UDP checksum is computed for sent IPv4 packets
+	UDPCsum *bool
+
+	// Specifies how the DF bit of sent IPv4 packets is set
+	DF *GeneveDF
+
+	// Specifies whether the inner protocol is taken from the encapsulated packet,
+	// allowing the tunnel to carry IP packets without an Ethernet header
+	InnerProtoInherit bool
+}
+
+var _ rtnetlink.LinkDriverVerifier = &Geneve{}
+
+func (g *Geneve) New() rtnetlink.LinkDriver {
+	return &Geneve{}
+}
+
+func (g *Geneve) Verify(msg *rtnetlink.LinkMessage) error {
+	if g.Remote != nil && g.Remote.To16() == nil {
+		return fmt.Errorf("invalid geneve remote address %s", g.Remote)
+	}
+	// The kernel reports a TTL of 0 for tunnels inheriting the TTL, which
+	// is consistent.
+	if g.TTLInherit && g.TTL != nil && *g.TTL != 0 {
+		return errors.New("geneve TTL and TTLInherit are mutually exclusive")
+	}
+	if g.DF != nil && *g.DF > GeneveDFInherit {
+		return fmt.Errorf("invalid geneve DF mode %s", *g.DF)
+	}
+	return nil
+}
+
+func (g *Geneve) Encode(ae *netlink.AttributeEncoder) error {
+	if g.ID != nil {
+		ae.Uint32(unix.IFLA_GENEVE_ID, *g.ID)
+	}
+	if g.Remote != nil {
+		if ip := g.Remote.To4(); ip != nil {
+			ae.Bytes(unix.IFLA_GENEVE_REMOTE, ip)
+		} else {
+			ae.Bytes(unix.IFLA_GENEVE_REMOTE6, g.Remote.To16())
+		}
+	}
+	if g.TTL != nil {
+		ae.Uint8(unix.IFLA_GENEVE_TTL, *g.TTL)
+	}
+	if g.TTLInherit {
+		ae.Uint8(unix.IFLA_GENEVE_TTL_INHERIT, 1)
+	}
+	if g.TOS != nil {
+		ae.Uint8(unix.IFLA_GENEVE_TOS, *g.TOS)
+	}
+	if g.Port != nil {
+		encodeBE16(ae, unix.IFLA_GENEVE_PORT, *g.Port)
+	}
+	if g.CollectMetadata {
+		ae.Flag(unix.IFLA_GENEVE_COLLECT_METADATA, true)
+	}
+	if g.UDPCsum != nil {
+		ae.Uint8(unix.IFLA_GENEVE_UDP_CSUM, boolToUint8(*g.UDPCsum))
+	}
+	if g.DF != nil {
+		ae.Uint8(unix.IFLA_GENEVE_DF, uint8(*g.DF))
+	}
+	if g.InnerProtoInherit {
+		ae.Flag(unix.IFLA_GENEVE_INNER_PROTO_INHERIT, true)
+	}
+	return nil
+}
+
+func (g *Geneve) Decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_GENEVE_ID:
+			v := ad.Uint32()
+			g.ID = &v
+		case unix.IFLA_GENEVE_REMOTE, unix.IFLA_GENEVE_REMOTE6:
+			g.Remote = ad.Bytes()
+		case unix.IFLA_GENEVE_TTL:
+			v := ad.Uint8()
+			g.TTL = &v
+		case unix.IFLA_GENEVE_TTL_INHERIT:
+			g.TTLInherit = ad.Uint8() != 0
+		case unix.IFLA_GENEVE_TOS:
+			v := ad.Uint8()
+			g.TOS = &v
+		case unix.IFLA_GENEVE_PORT:
+			v, err := decodeBE16(ad)
+			if err != nil {
+				return err
+			}
+			g.Port = &v
+		case unix.IFLA_GENEVE_COLLECT_METADATA:
+			g.CollectMetadata = true
+		case unix.IFLA_GENEVE_UDP_CSUM:
+			v := ad.Uint8() != 0
+			g.UDPCsum = &v
+		case unix.IFLA_GENEVE_DF:
+			v := GeneveDF(ad.Uint8())
+			g.DF = &v
+		case unix.IFLA_GENEVE_INNER_PROTO_INHERIT:
+			g.InnerProtoInherit = true
+		}
+	}
+	return nil
+}
+
+func (*Geneve) Kind() string {
+	return "geneve"
+}
@@ -0,0 +1,216 @@
+package driver
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/mdlayher/netlink"
+)
+
+func TestGeneveRoundTrip(t *testing.T) {
+	var (
+		id   uint32 = 100
+		ttl  uint8  = 64
+		port uint16 = 6081
+		csum        = true
+		df          = GeneveDFInherit
+	)
+
+	tests := []struct {
+		name   string
+		geneve *Geneve
+	}{
+		{
+			name:   "empty",
+			geneve: &Geneve{},
+		},
+		{
+			name: "IPv4 remote",
+			geneve: &Geneve{
+				ID:      &id,
+				Remote:  net.IPv4(192, 0, 2, 1).To4(),
+				TTL:     &ttl,
+				TOS:     &ttl,
+				Port:    &port,
+				UDPCsum: &csum,
+				DF:      &df,
+			},
+		},
+		{
+			name: "IPv6 remote",
+			geneve: &Geneve{
+				ID:     &id,
+				Remote: net.ParseIP("2001:db8::1"),
+				Port:   &port,
+			},
+		},
+		{
+			name: "collect metadata",
+			geneve: &Geneve{
+				CollectMetadata:   true,
+				TTLInherit:        true,
+				InnerProtoInherit: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundTrip(tt.geneve)
+			if err != nil {
+				t.Fatalf("failed to round trip geneve: %v", err)
+			}
+
+			if diff := cmp.Diff(rtnetlink.LinkDriver(tt.geneve), got); diff != "" {
+				t.Fatalf("unexpected geneve (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGeneveEncode(t *testing.T) {
+	var (
+		port uint16 = 6081
+		csum        = true
+	)
+
+	tests := []struct {
+		name   string
+		geneve *Geneve
+		b      []byte
+	}{
+		{
+			name: "IPv4 remote",
+			geneve: &Geneve{
+				Remote: net.IPv4(192, 0, 2, 1),
+			},
+			b: []byte{
+				0x08, 0x00, 0x02, 0x00, 0xc0, 0x00, 0x02, 0x01,
+			},
+		},
+		{
+			name: "IPv6 remote",
+			geneve: &Geneve{
+				Remote: net.ParseIP("2001:db8::1"),
+			},
+			b: []byte{
+				0x14, 0x00, 0x07, 0x00,
+				0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			},
+		},
+		{
+			name: "port",
+			geneve: &Geneve{
+				Port: &port,
+			},
+			b: []byte{
+				0x06, 0x00, 0x05, 0x00, 0x17, 0xc1, 0x00, 0x00,
+			},
+		},
+		{
+			name: "udp checksum",
+			geneve: &Geneve{
+				UDPCsum: &csum,
+			},
+			b: []byte{
+				0x05, 0x00, 0x08, 0x00, 0x01, 0x00, 0x00, 0x00,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ae := netlink.NewAttributeEncoder()
+			if err := tt.geneve.Encode(ae); err != nil {
+				t.Fatalf("failed to encode geneve: %v", err)
+			}
+			b, err := ae.Encode()
+			if err != nil {
+				t.Fatalf("failed to encode attributes: %v", err)
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("unexpected bytes:\n- want: [%# x]\n-  got: [%# x]", want, got)
+			}
+		})
+	}
+}
+
+func TestGeneveVerify(t *testing.T) {
+	var (
+		ttl     uint8 = 64
+		inherit uint8
+		df      = GeneveDFSet
+		invalid = GeneveDF(3)
+	)
+
+	tests := []struct {
+		name   string
+		geneve *Geneve
+		ok     bool
+	}{
+		{
+			name:   "empty",
+			geneve: &Geneve{},
+			ok:     true,
+		},
+		{
+			name: "fixed TTL and DF",
+			geneve: &Geneve{
+				TTL: &ttl,
+				DF:  &df,
+			},
+			ok: true,
+		},
+		{
+			name: "TTL inherit",
+			geneve: &Geneve{
+				TTLInherit: true,
+			},
+			ok: true,
+		},
+		{
+			name: "TTL inherit with zero TTL",
+			geneve: &Geneve{
+				TTL:        &inherit,
+				TTLInherit: true,
+			},
+			ok: true,
+		},
+		{
+			name: "TTL inherit with fixed TTL",
+			geneve: &Geneve{
+				TTL:        &ttl,
+				TTLInherit: true,
+			},
+		},
+		{
+			name: "invalid DF",
+			geneve: &Geneve{
+				DF: &invalid,
+			},
+		},
+		{
+			name: "invalid remote",
+			geneve: &Geneve{
+				Remote: net.IP{192, 0, 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.geneve.Verify(&rtnetlink.LinkMessage{})
+			if tt.ok && err != nil {
+				t.Fatalf("failed to verify geneve: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package driver
+
+import (
+	"net"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+// gre_key is the GRE header flag indicating the presence of a key, it is
+// managed through the IKey and OKey fields.
+const gre_key = 0x2000
+
+const (
+	gre_base_hlen = 4  // GRE header without optional fields
+	gre_opt_hlen  = 4  // each of the checksum, key and sequence fields
+	iphdr_len     = 20 // outer IPv4 header without options
+	eth_hlen      = 14 // Ethernet header of gretap frames
+)
+
+// GreFlags specifies the optional fields of the GRE header
+type GreFlags uint16
+
+const (
+	// The GRE header carries a checksum
+	GreFlagChecksum GreFlags = 0x8000
+
+	// The GRE header carries a sequence number
+	GreFlagSeq GreFlags = 0x1000
+)
+
+// Gre implements LinkDriverVerifier for the gre driver
+type Gre struct {
+	// Specifies the local IPv4 address of the tunnel
+	Local net.IP
+
+	// Specifies the remote IPv4 address of the tunnel
+	Remote net.IP
+
+	// Specifies the index of the underlying device used for the tunnel
+	Link *uint32
+
+	// Specifies the GRE header flags expected on received packets
+	IFlags GreFlags
+
+	// Specifies the GRE header flags of sent packets
+	OFlags GreFlags
+
+	// Specifies the key of received packets, enables keyed GRE for received packets when set
+	IKey *uint32
+
+	// Specifies the key of sent packets, enables keyed GRE for sent packets when set
+	OKey *uint32
+
+	// Specifies the TTL of sent packets, 0 means the TTL is inherited from the encapsulated packet
+	TTL *uint8
+
+	// Specifies the TOS of sent packets, 1 means the TOS is inherited from the encapsulated packet
+	TOS *uint8
+
+	// Specifies whether path MTU discovery is enabled on the tunnel
+	PMTUDisc *uint8
+
+	// Specifies the UDP encapsulation of the tunnel
+	EncapType *TunnelEncap
+
+	// Specifies the options of the UDP encapsulation
+	EncapFlags *TunnelEncapFlags
+
+	// Specifies the UDP source port of the encapsulation, 0 selects a random source port per flow
+	EncapSport *uint16
+
+	// Specifies the UDP destination port of the encapsulation
+	EncapDport *uint16
+
+	// Specifies whether the tunnel is flow based, taking its parameters from the packet metadata
+	CollectMetadata bool
+
+	// Specifies whether the DF bit is ignored, allowing fragmentation of sent packets
+	IgnoreDF *uint8
+
+	// Specifies the firewall mark of sent packets
+	FwMark *uint32
+}
+
+var _ rtnetlink.LinkDriverVerifier = &Gre{}
+
+func (g *Gre) New() rtnetlink.LinkDriver {
+	return &Gre{}
+}
+
+func (g *Gre) Verify(msg *rtnetlink.LinkMessage) error {
+	if err := verifyIPv4Endpoints(g.Kind(), g.Local, g.Remote); err != nil {
+		return err
+	}
+	return verifyMTU(msg, eth_min_mtu, g.maxMTU())
+}
+
+// maxMTU returns the largest MTU the kernel accepts for the tunnel, the
+// maximum IP packet size minus the outer IPv4 and GRE headers of sent
+// packets. The headers of a UDP encapsulation are not accounted for.
+func (g *Gre) maxMTU() uint32 {
+	hlen := uint32(iphdr_len + gre_base_hlen)
+	if g.OKey != nil {
+		hlen += gre_opt_hlen
+	}
+	if g.OFlags&GreFlagChecksum != 0 {
+		hlen += gre_opt_hlen
+	}
+	if g.OFlags&GreFlagSeq != 0 {
+		hlen += gre_opt_hlen
+	}
+	return eth_max_mtu - hlen
+}
+
+func (g *Gre) Encode(ae *netlink.AttributeEncoder) error {
+	g.encode(ae)
+	return nil
+}
+
+func (g *Gre) Decode(ad *netlink.AttributeDecoder) error {
+	return g.decode(ad)
+}
+
+func (*Gre) Kind() string {
+	return "gre"
+}
+
+// encode encodes the IFLA_GRE_* attributes shared by the gre and gretap
+// drivers.
+func (g *Gre) encode(ae *netlink.AttributeEncoder) {
+	if g.Link != nil {
+		ae.Uint32(unix.IFLA_GRE_LINK, *g.Link)
+	}
+	iflags, oflags := uint16(g.IFlags), uint16(g.OFlags)
+	if g.IKey != nil {
+		iflags |= gre_key
+		encodeBE32(ae, unix.IFLA_GRE_IKEY, *g.IKey)
+	}
+	if g.OKey != nil {
+		oflags |= gre_key
+		encodeBE32(ae, unix.IFLA_GRE_OKEY, *g.OKey)
+	}
+	if iflags != 0 {
+		encodeBE16(ae, unix.IFLA_GRE_IFLAGS, iflags)
+	}
+	if oflags != 0 {
+		encodeBE16(ae, unix.IFLA_GRE_OFLAGS, oflags)
+	}
+	if g.Local != nil {
+		ae.Bytes(unix.IFLA_GRE_LOCAL, g.Local.To4())
+	}
+	if g.Remote != nil {
+		ae.Bytes(unix.IFLA_GRE_REMOTE, g.Remote.To4())
+	}
+	if g.TTL != nil {
+		ae.Uint8(unix.IFLA_GRE_TTL, *g.TTL)
+	}
+	if g.TOS != nil {
+		ae.Uint8(unix.IFLA_GRE_TOS, *g.TOS)
+	}
+	if g.PMTUDisc != nil {
+		ae.Uint8(unix.IFLA_GRE_PMTUDISC, *g.PMTUDisc)
+	}
+	if g.EncapType != nil {
+		ae.Uint16(unix.IFLA_GRE_ENCAP_TYPE, uint16(*g.EncapType))
+	}
+	if g.EncapFlags != nil {
+		ae.Uint16(unix.IFLA_GRE_ENCAP_FLAGS, uint16(*g.EncapFlags))
+	}
+	if g.EncapSport != nil {
+		encodeBE16(ae, unix.IFLA_GRE_ENCAP_SPORT, *g.EncapSport)
+	}
+	if g.EncapDport != nil {
+		encodeBE16(ae, unix.IFLA_GRE_ENCAP_DPORT, *g.EncapDport)
+	}
+	if g.CollectMetadata {
+		ae.Flag(unix.IFLA_GRE_COLLECT_METADATA, true)
+	}
+	if g.IgnoreDF != nil {
+		ae.Uint8(unix.IFLA_GRE_IGNORE_DF, *g.IgnoreDF)
+	}
+	if g.FwMark != nil {
+		ae.Uint32(unix.IFLA_GRE_FWMARK, *g.FwMark)
+	}
+}
+
+// decode decodes the IFLA_GRE_* attributes shared by the gre and gretap
+// drivers.
+func (g *Gre) decode(ad *netlink.AttributeDecoder) error {
+	var ikey, okey *uint32
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_GRE_LINK:
+			v := ad.Uint32()
+			g.Link = &v
+		case unix.IFLA_GRE_IFLAGS:
+			v, err := decodeBE16(ad)
+			if err != nil {
+				return err
+			}
+			g.IFlags = GreFlags(v)
+		case unix.IFLA_GRE_OFLAGS:
+			v, err := decodeBE16(ad)
+			if err != nil {
+				return err
+			}
+			g.OFlags = GreFlags(v)
+		case unix.IFLA_GRE_IKEY:
+			v, err := decodeBE32(ad)
+			if err != nil {
+				return err
+			}
+			ikey = &v
+		case unix.IFLA_GRE_OKEY:
+			v, err := decodeBE32(ad)
+			if err != nil {
+				return err
+			}
+			okey = &v
+		case unix.IFLA_GRE_LOCAL:
+			g.Local = ad.Bytes()
+		case unix.IFLA_GRE_REMOTE:
+			g.Remote = ad.Bytes()
+		case unix.IFLA_GRE_TTL:
+			v := ad.Uint8()
+			g.TTL = &v
+		case unix.IFLA_GRE_TOS:
+			v := ad.Uint8()
+			g.TOS = &v
+		case unix.IFLA_GRE_PMTUDISC:
+			v := ad.Uint8()
+			g.PMTUDisc = &v
+		case unix.IFLA_GRE_ENCAP_TYPE:
+			v := TunnelEncap(ad.Uint16())
+			g.EncapType = &v
+		case unix.IFLA_GRE_ENCAP_FLAGS:
+			v := TunnelEncapFlags(ad.Uint16())
+			g.EncapFlags = &v
+		case unix.IFLA_GRE_ENCAP_SPORT:
+			v, err := decodeBE16(ad)
+			if err != nil {
+				return err
+			}
+			g.EncapSport = &v
+		case unix.IFLA_GRE_ENCAP_DPORT:
+			v, err := decodeBE16(ad)
+			if err != nil {
+				return err
+			}
+			g.EncapDport = &v
+		case unix.IFLA_GRE_COLLECT_METADATA:
+			g.CollectMetadata = true
+		case unix.IFLA_GRE_IGNORE_DF:
+			v := ad.Uint8()
+			g.IgnoreDF = &v
+		case unix.IFLA_GRE_FWMARK:
+			v := ad.Uint32()
+			g.FwMark = &v
+		}
+	}
+
+	// The kernel always reports the keys, they are only in use when the key
+	// flag is set.
+	if g.IFlags&gre_key != 0 {
+		g.IKey = ikey
+		g.IFlags &^= gre_key
+	}
+	if g.OFlags&gre_key != 0 {
+		g.OKey = okey
+		g.OFlags &^= gre_key
+	}
+	return nil
+}
+
+// Gretap implements LinkDriverVerifier for the gretap driver, which carries
+// Ethernet frames over GRE so the link can be enslaved to a bridge. It
+// supports the same attributes as Gre.
+type Gretap Gre
+
+var _ rtnetlink.LinkDriverVerifier = &Gretap{}
+
+func (g *Gretap) New() rtnetlink.LinkDriver {
+	return &Gretap{}
+}
+
+func (g *Gretap) Verify(msg *rtnetlink.LinkMessage) error {
+	if err := verifyIPv4Endpoints(g.Kind(), g.Local, g.Remote); err != nil {
+		return err
+	}
+	// The encapsulated Ethernet header counts against the MTU as well.
+	return verifyMTU(msg, eth_min_mtu, (*Gre)(g).maxMTU()-eth_hlen)
+}
+
+func (g *Gretap) Encode(ae *netlink.AttributeEncoder) error {
+	(*Gre)(g).encode(ae)
+	return nil
+}
+
+func (g *Gretap) Decode(ad *netlink.AttributeDecoder) error {
+	return (*Gre)(g).decode(ad)
+}
+
+func (*Gretap) Kind() string {
+	return "gretap"
+}
@@ -0,0 +1,272 @@
+package driver
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/mdlayher/netlink"
+)
+
+func TestGreRoundTrip(t *testing.T) {
+	var (
+		u8        uint8  = 64
+		u32       uint32 = 10
+		key       uint32 = 0x01020304
+		auto      uint16
+		port      uint16 = 5555
+		fou              = TunnelEncapFOU
+		encapCsum        = TunnelEncapFlagCsum
+	)
+
+	tests := []struct {
+		name string
+		gre  *Gre
+	}{
+		{
+			name: "empty",
+			gre:  &Gre{},
+		},
+		{
+			name: "full",
+			gre: &Gre{
+				Local:           net.IPv4(192, 0, 2, 1).To4(),
+				Remote:          net.IPv4(192, 0, 2, 2).To4(),
+				Link:            &u32,
+				IFlags:          GreFlagChecksum | GreFlagSeq,
+				OFlags:          GreFlagSeq,
+				IKey:            &key,
+				OKey:            &key,
+				TTL:             &u8,
+				TOS:             &u8,
+				PMTUDisc:        &u8,
+				EncapType:       &fou,
+				EncapFlags:      &encapCsum,
+				EncapSport:      &port,
+				EncapDport:      &port,
+				CollectMetadata: true,
+				IgnoreDF:        &u8,
+				FwMark:          &u32,
+			},
+		},
+		{
+			name: "auto source port",
+			gre: &Gre{
+				EncapType:  &fou,
+				EncapSport: &auto,
+				EncapDport: &port,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundTrip(tt.gre)
+			if err != nil {
+				t.Fatalf("failed to round trip gre: %v", err)
+			}
+
+			if diff := cmp.Diff(rtnetlink.LinkDriver(tt.gre), got); diff != "" {
+				t.Fatalf("unexpected gre (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGreEncode(t *testing.T) {
+	var (
+		key  uint32 = 0x01020304
+		auto uint16
+		port uint16 = 0x15b3
+	)
+
+	tests := []struct {
+		name string
+		gre  *Gre
+		b    []byte
+	}{
+		{
+			name: "sequence numbers",
+			gre: &Gre{
+				IFlags: GreFlagSeq,
+				OFlags: GreFlagSeq,
+			},
+			b: []byte{
+				0x06, 0x00, 0x02, 0x00, 0x10, 0x00, 0x00, 0x00,
+				0x06, 0x00, 0x03, 0x00, 0x10, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			name: "key and sequence numbers",
+			gre: &Gre{
+				IFlags: GreFlagSeq,
+				IKey:   &key,
+			},
+			b: []byte{
+				0x08, 0x00, 0x04, 0x00, 0x01, 0x02, 0x03, 0x04,
+				0x06, 0x00, 0x02, 0x00, 0x30, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			name: "unset source port",
+			gre:  &Gre{},
+		},
+		{
+			name: "auto source port",
+			gre: &Gre{
+				EncapSport: &auto,
+			},
+			b: []byte{
+				0x06, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			name: "fixed source port",
+			gre: &Gre{
+				EncapSport: &port,
+			},
+			b: []byte{
+				0x06, 0x00, 0x10, 0x00, 0x15, 0xb3, 0x00, 0x00,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ae := netlink.NewAttributeEncoder()
+			if err := tt.gre.Encode(ae); err != nil {
+				t.Fatalf("failed to encode gre: %v", err)
+			}
+			b, err := ae.Encode()
+			if err != nil {
+				t.Fatalf("failed to encode attributes: %v", err)
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("unexpected bytes:\n- want: [%# x]\n-  got: [%# x]", want, got)
+			}
+		})
+	}
+}
+
+func TestGreVerify(t *testing.T) {
+	key := uint32(1)
+
+	tests := []struct {
+		name string
+		gre  *Gre
+		mtu  uint32
+		ok   bool
+	}{
+		{
+			name: "IPv4 endpoints",
+			gre: &Gre{
+				Local:  net.IPv4(192, 0, 2, 1),
+				Remote: net.IPv4(192, 0, 2, 2),
+			},
+			ok: true,
+		},
+		{
+			name: "IPv6 remote",
+			gre: &Gre{
+				Local:  net.IPv4(192, 0, 2, 1),
+				Remote: net.ParseIP("2001:db8::2"),
+			},
+		},
+		{
+			name: "MTU max",
+			gre:  &Gre{},
+			mtu:  65511,
+			ok:   true,
+		},
+		{
+			name: "MTU too large",
+			gre:  &Gre{},
+			mtu:  65512,
+		},
+		{
+			name: "MTU too small",
+			gre:  &Gre{},
+			mtu:  67,
+		},
+		{
+			name: "MTU max with key and checksum",
+			gre:  &Gre{OKey: &key, OFlags: GreFlagChecksum},
+			mtu:  65503,
+			ok:   true,
+		},
+		{
+			name: "MTU too large with key and checksum",
+			gre:  &Gre{OKey: &key, OFlags: GreFlagChecksum},
+			mtu:  65504,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.gre.Verify(&rtnetlink.LinkMessage{
+				Attributes: &rtnetlink.LinkAttributes{MTU: tt.mtu},
+			})
+			if tt.ok && err != nil {
+				t.Fatalf("failed to verify gre: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
+
+func TestGretapRoundTrip(t *testing.T) {
+	var (
+		ttl  uint8  = 64
+		ikey uint32 = 1
+		okey uint32 = 2
+	)
+
+	gretap := &Gretap{
+		Local:  net.IPv4(192, 0, 2, 1).To4(),
+		Remote: net.IPv4(192, 0, 2, 2).To4(),
+		IFlags: GreFlagChecksum | GreFlagSeq,
+		OFlags: GreFlagChecksum | GreFlagSeq,
+		IKey:   &ikey,
+		OKey:   &okey,
+		TTL:    &ttl,
+	}
+
+	if want, got := "gretap", gretap.Kind(); want != got {
+		t.Fatalf("unexpected kind, want: %q, got: %q", want, got)
+	}
+
+	got, err := RoundTrip(gretap)
+	if err != nil {
+		t.Fatalf("failed to round trip gretap: %v", err)
+	}
+	if diff := cmp.Diff(rtnetlink.LinkDriver(gretap), got); diff != "" {
+		t.Fatalf("unexpected gretap (-want +got):\n%s", diff)
+	}
+
+	if err := (&Gretap{Local: net.ParseIP("2001:db8::1")}).Verify(&rtnetlink.LinkMessage{}); err == nil {
+		t.Fatal("expected an error for an IPv6 endpoint, but none occurred")
+	}
+
+	for _, tt := range []struct {
+		mtu uint32
+		ok  bool
+	}{
+		{mtu: 65497, ok: true},
+		{mtu: 65498},
+	} {
+		err := (&Gretap{}).Verify(&rtnetlink.LinkMessage{
+			Attributes: &rtnetlink.LinkAttributes{MTU: tt.mtu},
+		})
+		if tt.ok && err != nil {
+			t.Fatalf("failed to verify MTU %d: %v", tt.mtu, err)
+		}
+		if !tt.ok && err == nil {
+			t.Fatalf("expected an error for MTU %d, but none occurred", tt.mtu)
+		}
+	}
+}
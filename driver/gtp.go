@@ -0,0 +1,85 @@
+package driver
+
+import (
+	"fmt"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+// GtpRole specifies the role of a GTP tunnel endpoint
+type GtpRole uint32
+
+func (g GtpRole) String() string {
+	switch g {
+	case GtpRoleGGSN:
+		return "ggsn"
+	case GtpRoleSGSN:
+		return "sgsn"
+	default:
+		return fmt.Sprintf("unknown GtpRole value (%d)", g)
+	}
+}
+
+const (
+	// The device acts as a gateway GPRS support node, this is the default
+	GtpRoleGGSN GtpRole = unix.GTP_ROLE_GGSN
+
+	// The device acts as a serving GPRS support node
+	GtpRoleSGSN GtpRole = unix.GTP_ROLE_SGSN
+)
+
+// Gtp implements LinkDriver for the gtp driver
+type Gtp struct {
+	FD0         *int32   // Specifies the file descriptor of the UDP socket used for GTPv0
+	FD1         *int32   // Specifies the file descriptor of the UDP socket used for GTPv1-U
+	PDPHashSize *uint32  // Specifies the size of the PDP context hash table
+	Role        *GtpRole // Specifies the role of the device
+}
+
+var _ rtnetlink.LinkDriver = &Gtp{}
+
+func (g *Gtp) New() rtnetlink.LinkDriver {
+	return &Gtp{}
+}
+
+func (g *Gtp) Encode(ae *netlink.AttributeEncoder) error {
+	if g.FD0 != nil {
+		ae.Int32(unix.IFLA_GTP_FD0, *g.FD0)
+	}
+	if g.FD1 != nil {
+		ae.Int32(unix.IFLA_GTP_FD1, *g.FD1)
+	}
+	if g.PDPHashSize != nil {
+		ae.Uint32(unix.IFLA_GTP_PDP_HASHSIZE, *g.PDPHashSize)
+	}
+	if g.Role != nil {
+		ae.Uint32(unix.IFLA_GTP_ROLE, uint32(*g.Role))
+	}
+	return nil
+}
+
+func (g *Gtp) Decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_GTP_FD0:
+			v := ad.Int32()
+			g.FD0 = &v
+		case unix.IFLA_GTP_FD1:
+			v := ad.Int32()
+			g.FD1 = &v
+		case unix.IFLA_GTP_PDP_HASHSIZE:
+			v := ad.Uint32()
+			g.PDPHashSize = &v
+		case unix.IFLA_GTP_ROLE:
+			v := GtpRole(ad.Uint32())
+			g.Role = &v
+		}
+	}
+	return nil
+}
+
+func (*Gtp) Kind() string {
+	return "gtp"
+}
@@ -0,0 +1,66 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+)
+
+func TestGtpRoundTrip(t *testing.T) {
+	var (
+		fd0      int32  = 3
+		fd1      int32  = 4
+		hashSize uint32 = 1024
+		sgsn            = GtpRoleSGSN
+	)
+
+	tests := []struct {
+		name string
+		gtp  *Gtp
+	}{
+		{
+			name: "empty",
+			gtp:  &Gtp{},
+		},
+		{
+			name: "full",
+			gtp: &Gtp{
+				FD0:         &fd0,
+				FD1:         &fd1,
+				PDPHashSize: &hashSize,
+				Role:        &sgsn,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundTrip(tt.gtp)
+			if err != nil {
+				t.Fatalf("failed to round trip gtp: %v", err)
+			}
+
+			if diff := cmp.Diff(rtnetlink.LinkDriver(tt.gtp), got); diff != "" {
+				t.Fatalf("unexpected gtp (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGtpRoleString(t *testing.T) {
+	tests := []struct {
+		r GtpRole
+		s string
+	}{
+		{r: GtpRoleGGSN, s: "ggsn"},
+		{r: GtpRoleSGSN, s: "sgsn"},
+		{r: 2, s: "unknown GtpRole value (2)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.r.String(); got != tt.s {
+			t.Errorf("unexpected string for role %d: want %q, got %q", uint32(tt.r), tt.s, got)
+		}
+	}
+}
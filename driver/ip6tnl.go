@@ -0,0 +1,137 @@
+package driver
+
+import (
+	"net"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+// Ip6tnlFlags specifies options of an ip6tnl tunnel
+type Ip6tnlFlags uint32
+
+const (
+	// Do not add the tunnel encapsulation limit option to sent packets
+	Ip6tnlFlagIgnoreEncapLimit Ip6tnlFlags = 1 << iota
+
+	// Copy the traffic class of the encapsulated packet
+	Ip6tnlFlagUseOrigTClass
+
+	// Copy the flow label of the encapsulated packet
+	Ip6tnlFlagUseOrigFlowLabel
+
+	// The tunnel is used as a Mobile IPv6 device
+	Ip6tnlFlagMIP6Dev
+
+	// Copy the DSCP of the outer header into the inner header of received packets
+	Ip6tnlFlagRcvDSCPCopy
+
+	// Copy the firewall mark of the encapsulated packet
+	Ip6tnlFlagUseOrigFwMark
+
+	// Allow the local and remote endpoints to be addresses of the local host
+	Ip6tnlFlagAllowLocalRemote
+)
+
+// Ip6tnl implements LinkDriverVerifier for the ip6tnl driver
+type Ip6tnl struct {
+	// Specifies the local IPv6 address of the tunnel
+	Local net.IP
+
+	// Specifies the remote IPv6 address of the tunnel
+	Remote net.IP
+
+	// Specifies the index of the underlying device used for the tunnel
+	Link *uint32
+
+	// Specifies the hop limit of sent packets
+	TTL *uint8
+
+	// Specifies the tunnel encapsulation limit of sent packets
+	EncapLimit *uint8
+
+	// Specifies the traffic class and flow label of sent packets, as in the
+	// first 32 bits of the IPv6 header without the version
+	FlowInfo *uint32
+
+	// Specifies the options of the tunnel
+	Flags Ip6tnlFlags
+
+	// Specifies the encapsulated protocol, IPPROTO_IPV6 or IPPROTO_IPIP, 0 accepts both
+	Proto *uint8
+}
+
+var _ rtnetlink.LinkDriverVerifier = &Ip6tnl{}
+
+func (i *Ip6tnl) New() rtnetlink.LinkDriver {
+	return &Ip6tnl{}
+}
+
+func (i *Ip6tnl) Verify(msg *rtnetlink.LinkMessage) error {
+	return verifyIPv6Endpoints(i.Kind(), i.Local, i.Remote)
+}
+
+func (i *Ip6tnl) Encode(ae *netlink.AttributeEncoder) error {
+	if i.Link != nil {
+		ae.Uint32(unix.IFLA_IPTUN_LINK, *i.Link)
+	}
+	if i.Local != nil {
+		ae.Bytes(unix.IFLA_IPTUN_LOCAL, i.Local.To16())
+	}
+	if i.Remote != nil {
+		ae.Bytes(unix.IFLA_IPTUN_REMOTE, i.Remote.To16())
+	}
+	if i.TTL != nil {
+		ae.Uint8(unix.IFLA_IPTUN_TTL, *i.TTL)
+	}
+	if i.EncapLimit != nil {
+		ae.Uint8(unix.IFLA_IPTUN_ENCAP_LIMIT, *i.EncapLimit)
+	}
+	if i.FlowInfo != nil {
+		encodeBE32(ae, unix.IFLA_IPTUN_FLOWINFO, *i.FlowInfo)
+	}
+	if i.Flags != 0 {
+		ae.Uint32(unix.IFLA_IPTUN_FLAGS, uint32(i.Flags))
+	}
+	if i.Proto != nil {
+		ae.Uint8(unix.IFLA_IPTUN_PROTO, *i.Proto)
+	}
+	return nil
+}
+
+func (i *Ip6tnl) Decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_IPTUN_LINK:
+			v := ad.Uint32()
+			i.Link = &v
+		case unix.IFLA_IPTUN_LOCAL:
+			i.Local = ad.Bytes()
+		case unix.IFLA_IPTUN_REMOTE:
+			i.Remote = ad.Bytes()
+		case unix.IFLA_IPTUN_TTL:
+			v := ad.Uint8()
+			i.TTL = &v
+		case unix.IFLA_IPTUN_ENCAP_LIMIT:
+			v := ad.Uint8()
+			i.EncapLimit = &v
+		case unix.IFLA_IPTUN_FLOWINFO:
+			v, err := decodeBE32(ad)
+			if err != nil {
+				return err
+			}
+			i.FlowInfo = &v
+		case unix.IFLA_IPTUN_FLAGS:
+			i.Flags = Ip6tnlFlags(ad.Uint32())
+		case unix.IFLA_IPTUN_PROTO:
+			v := ad.Uint8()
+			i.Proto = &v
+		}
+	}
+	return ad.Err()
+}
+
+func (*Ip6tnl) Kind() string {
+	return "ip6tnl"
+}
@@ -0,0 +1,97 @@
+package driver
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+)
+
+func TestIp6tnlRoundTrip(t *testing.T) {
+	var (
+		link       uint32 = 2
+		ttl        uint8  = 64
+		encapLimit uint8  = 4
+		flowInfo   uint32 = 0x00012345
+		proto      uint8  = 41
+	)
+
+	tests := []struct {
+		name   string
+		ip6tnl *Ip6tnl
+	}{
+		{
+			name:   "empty",
+			ip6tnl: &Ip6tnl{},
+		},
+		{
+			name: "full",
+			ip6tnl: &Ip6tnl{
+				Local:      net.ParseIP("2001:db8::1"),
+				Remote:     net.ParseIP("2001:db8::2"),
+				Link:       &link,
+				TTL:        &ttl,
+				EncapLimit: &encapLimit,
+				FlowInfo:   &flowInfo,
+				Flags:      Ip6tnlFlagIgnoreEncapLimit | Ip6tnlFlagUseOrigTClass,
+				Proto:      &proto,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundTrip(tt.ip6tnl)
+			if err != nil {
+				t.Fatalf("failed to round trip ip6tnl: %v", err)
+			}
+
+			if diff := cmp.Diff(rtnetlink.LinkDriver(tt.ip6tnl), got); diff != "" {
+				t.Fatalf("unexpected ip6tnl (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIp6tnlVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		ip6tnl *Ip6tnl
+		ok     bool
+	}{
+		{
+			name: "IPv6 endpoints",
+			ip6tnl: &Ip6tnl{
+				Local:  net.ParseIP("2001:db8::1"),
+				Remote: net.ParseIP("2001:db8::2"),
+			},
+			ok: true,
+		},
+		{
+			name: "IPv4 remote",
+			ip6tnl: &Ip6tnl{
+				Local:  net.ParseIP("2001:db8::1"),
+				Remote: net.IPv4(192, 0, 2, 2),
+			},
+		},
+		{
+			name: "invalid local",
+			ip6tnl: &Ip6tnl{
+				Local: net.IP{0x20, 0x01},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ip6tnl.Verify(&rtnetlink.LinkMessage{})
+			if tt.ok && err != nil {
+				t.Fatalf("failed to verify ip6tnl: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package driver
+
+import (
+	"net"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+// Ipip implements LinkDriverVerifier for the ipip driver
+type Ipip struct {
+	// Specifies the local IPv4 address of the tunnel
+	Local net.IP
+
+	// Specifies the remote IPv4 address of the tunnel
+	Remote net.IP
+
+	// Specifies the index of the underlying device used for the tunnel
+	Link *uint32
+
+	// Specifies the TTL of sent packets, 0 means the TTL is inherited from the encapsulated packet
+	TTL *uint8
+
+	// Specifies the TOS of sent packets, 1 means the TOS is inherited from the encapsulated packet
+	TOS *uint8
+
+	// Specifies whether path MTU discovery is enabled on the tunnel
+	PMTUDisc *uint8
+}
+
+var _ rtnetlink.LinkDriverVerifier = &Ipip{}
+
+func (i *Ipip) New() rtnetlink.LinkDriver {
+	return &Ipip{}
+}
+
+func (i *Ipip) Verify(msg *rtnetlink.LinkMessage) error {
+	return verifyIPv4Endpoints(i.Kind(), i.Local, i.Remote)
+}
+
+func (i *Ipip) Encode(ae *netlink.AttributeEncoder) error {
+	i.encode(ae)
+	return nil
+}
+
+func (i *Ipip) Decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		i.decodeAttr(ad)
+	}
+	return nil
+}
+
+func (*Ipip) Kind() string {
+	return "ipip"
+}
+
+// encode encodes the IFLA_IPTUN_* attributes shared by the IPv4 tunnel
+// drivers.
+func (i *Ipip) encode(ae *netlink.AttributeEncoder) {
+	if i.Link != nil {
+		ae.Uint32(unix.IFLA_IPTUN_LINK, *i.Link)
+	}
+	if i.Local != nil {
+		ae.Bytes(unix.IFLA_IPTUN_LOCAL, i.Local.To4())
+	}
+	if i.Remote != nil {
+		ae.Bytes(unix.IFLA_IPTUN_REMOTE, i.Remote.To4())
+	}
+	if i.TTL != nil {
+		ae.Uint8(unix.IFLA_IPTUN_TTL, *i.TTL)
+	}
+	if i.TOS != nil {
+		ae.Uint8(unix.IFLA_IPTUN_TOS, *i.TOS)
+	}
+	if i.PMTUDisc != nil {
+		ae.Uint8(unix.IFLA_IPTUN_PMTUDISC, *i.PMTUDisc)
+	}
+}
+
+// decodeAttr decodes the current attribute of ad if it is one of the
+// IFLA_IPTUN_* attributes shared by the IPv4 tunnel drivers, and reports
+// whether it did.
+func (i *Ipip) decodeAttr(ad *netlink.AttributeDecoder) bool {
+	switch ad.Type() {
+	case unix.IFLA_IPTUN_LINK:
+		v := ad.Uint32()
+		i.Link = &v
+	case unix.IFLA_IPTUN_LOCAL:
+		i.Local = ad.Bytes()
+	case unix.IFLA_IPTUN_REMOTE:
+		i.Remote = ad.Bytes()
+	case unix.IFLA_IPTUN_TTL:
+		v := ad.Uint8()
+		i.TTL = &v
+	case unix.IFLA_IPTUN_TOS:
+		v := ad.Uint8()
+		i.TOS = &v
+	case unix.IFLA_IPTUN_PMTUDISC:
+		v := ad.Uint8()
+		i.PMTUDisc = &v
+	default:
+		return false
+	}
+	return true
+}
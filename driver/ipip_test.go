@@ -0,0 +1,85 @@
+package driver
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+)
+
+func TestIpipRoundTrip(t *testing.T) {
+	var (
+		u8  uint8  = 64
+		u32 uint32 = 10
+	)
+
+	tests := []struct {
+		name string
+		ipip *Ipip
+	}{
+		{
+			name: "empty",
+			ipip: &Ipip{},
+		},
+		{
+			name: "full",
+			ipip: &Ipip{
+				Local:    net.IPv4(192, 0, 2, 1).To4(),
+				Remote:   net.IPv4(192, 0, 2, 2).To4(),
+				Link:     &u32,
+				TTL:      &u8,
+				TOS:      &u8,
+				PMTUDisc: &u8,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundTrip(tt.ipip)
+			if err != nil {
+				t.Fatalf("failed to round trip ipip: %v", err)
+			}
+
+			if diff := cmp.Diff(rtnetlink.LinkDriver(tt.ipip), got); diff != "" {
+				t.Fatalf("unexpected ipip (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIpipVerify(t *testing.T) {
+	tests := []struct {
+		name string
+		ipip *Ipip
+		ok   bool
+	}{
+		{
+			name: "IPv4 endpoints",
+			ipip: &Ipip{
+				Local:  net.IPv4(192, 0, 2, 1),
+				Remote: net.IPv4(192, 0, 2, 2),
+			},
+			ok: true,
+		},
+		{
+			name: "IPv6 local",
+			ipip: &Ipip{
+				Local: net.ParseIP("2001:db8::1"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ipip.Verify(&rtnetlink.LinkMessage{})
+			if tt.ok && err != nil {
+				t.Fatalf("failed to verify ipip: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package driver
+
+import (
+	"fmt"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+// IpvlanMode specifies the layer at which ipvlan operates
+type IpvlanMode uint16
+
+func (i IpvlanMode) String() string {
+	switch i {
+	case IpvlanModeL2:
+		return "l2"
+	case IpvlanModeL3:
+		return "l3"
+	case IpvlanModeL3S:
+		return "l3s"
+	default:
+		return fmt.Sprintf("unknown IpvlanMode value (%d)", i)
+	}
+}
+
+const (
+	// Ipvlan operates on layer2, the parent device handles switching and
+	// neighbour discovery
+	IpvlanModeL2 IpvlanMode = unix.IPVLAN_MODE_L2
+
+	// Ipvlan operates on layer3, routing packets through the parent device
+	IpvlanModeL3 IpvlanMode = unix.IPVLAN_MODE_L3
+
+	// Ipvlan operates on layer3 like IpvlanModeL3, but packets traverse the
+	// netfilter hooks of the parent namespace
+	IpvlanModeL3S IpvlanMode = unix.IPVLAN_MODE_L3S
+)
+
+// IpvlanFlags specifies how traffic between ipvlan slaves of the same parent
+// device is handled
+type IpvlanFlags uint16
+
+func (i IpvlanFlags) String() string {
+	switch i {
+	case IpvlanFlagBridge:
+		return "bridge"
+	case IpvlanFlagPrivate:
+		return "private"
+	case IpvlanFlagVepa:
+		return "vepa"
+	default:
+		return fmt.Sprintf("unknown IpvlanFlags value (%d)", i)
+	}
+}
+
+const (
+	// Slaves communicate with each other directly, this is the default
+	IpvlanFlagBridge IpvlanFlags = 0
+
+	// Slaves are not allowed to communicate with each other
+	IpvlanFlagPrivate IpvlanFlags = unix.IPVLAN_F_PRIVATE
+
+	// Traffic between slaves is sent out through the parent device
+	IpvlanFlagVepa IpvlanFlags = unix.IPVLAN_F_VEPA
+)
+
+// Ipvlan implements LinkDriver for the ipvlan driver
+type Ipvlan struct {
+	Mode  *IpvlanMode  // Specifies driver operation mode
+	Flags *IpvlanFlags // Specifies traffic handling between slaves
+}
+
+var _ rtnetlink.LinkDriver = &Ipvlan{}
+
+func (i *Ipvlan) New() rtnetlink.LinkDriver {
+	return &Ipvlan{}
+}
+
+func (i *Ipvlan) Encode(ae *netlink.AttributeEncoder) error {
+	if i.Mode != nil {
+		ae.Uint16(unix.IFLA_IPVLAN_MODE, uint16(*i.Mode))
+	}
+	if i.Flags != nil {
+		ae.Uint16(unix.IFLA_IPVLAN_FLAGS, uint16(*i.Flags))
+	}
+	return nil
+}
+
+func (i *Ipvlan) Decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_IPVLAN_MODE:
+			v := IpvlanMode(ad.Uint16())
+			i.Mode = &v
+		case unix.IFLA_IPVLAN_FLAGS:
+			v := IpvlanFlags(ad.Uint16())
+			i.Flags = &v
+		}
+	}
+	return nil
+}
+
+func (*Ipvlan) Kind() string {
+	return "ipvlan"
+}
@@ -0,0 +1,85 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+)
+
+func TestIpvlanRoundTrip(t *testing.T) {
+	newIpvlan := func(m IpvlanMode, f IpvlanFlags) *Ipvlan {
+		return &Ipvlan{Mode: &m, Flags: &f}
+	}
+
+	tests := []struct {
+		name   string
+		ipvlan *Ipvlan
+	}{
+		{
+			name:   "empty",
+			ipvlan: &Ipvlan{},
+		},
+		{
+			name:   "l2 bridge",
+			ipvlan: newIpvlan(IpvlanModeL2, IpvlanFlagBridge),
+		},
+		{
+			name:   "l3 private",
+			ipvlan: newIpvlan(IpvlanModeL3, IpvlanFlagPrivate),
+		},
+		{
+			name:   "l3s vepa",
+			ipvlan: newIpvlan(IpvlanModeL3S, IpvlanFlagVepa),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundTrip(tt.ipvlan)
+			if err != nil {
+				t.Fatalf("failed to round trip ipvlan: %v", err)
+			}
+
+			if diff := cmp.Diff(rtnetlink.LinkDriver(tt.ipvlan), got); diff != "" {
+				t.Fatalf("unexpected ipvlan (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIpvlanModeString(t *testing.T) {
+	tests := []struct {
+		m IpvlanMode
+		s string
+	}{
+		{m: IpvlanModeL2, s: "l2"},
+		{m: IpvlanModeL3, s: "l3"},
+		{m: IpvlanModeL3S, s: "l3s"},
+		{m: 3, s: "unknown IpvlanMode value (3)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.s {
+			t.Errorf("unexpected string for mode %d: want %q, got %q", uint16(tt.m), tt.s, got)
+		}
+	}
+}
+
+func TestIpvlanFlagsString(t *testing.T) {
+	tests := []struct {
+		f IpvlanFlags
+		s string
+	}{
+		{f: IpvlanFlagBridge, s: "bridge"},
+		{f: IpvlanFlagPrivate, s: "private"},
+		{f: IpvlanFlagVepa, s: "vepa"},
+		{f: 3, s: "unknown IpvlanFlags value (3)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.f.String(); got != tt.s {
+			t.Errorf("unexpected string for flags %d: want %q, got %q", uint16(tt.f), tt.s, got)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package driver
+
+import (
+	"fmt"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+// MacsecValidation specifies how received frames are validated
+type MacsecValidation uint8
+
+func (m MacsecValidation) String() string {
+	switch m {
+	case MacsecValidationDisabled:
+		return "disabled"
+	case MacsecValidationCheck:
+		return "check"
+	case MacsecValidationStrict:
+		return "strict"
+	default:
+		return fmt.Sprintf("unknown MacsecValidation value (%d)", m)
+	}
+}
+
+const (
+	// Received frames are not validated
+	MacsecValidationDisabled MacsecValidation = unix.MACSEC_VALIDATE_DISABLED
+
+	// Received frames are validated, but invalid frames are still accepted
+	MacsecValidationCheck MacsecValidation = unix.MACSEC_VALIDATE_CHECK
+
+	// Received frames are validated and invalid frames are dropped, this is the default
+	MacsecValidationStrict MacsecValidation = unix.MACSEC_VALIDATE_STRICT
+)
+
+// MacsecCipherSuite specifies the cipher suite used to protect frames
+type MacsecCipherSuite uint64
+
+func (m MacsecCipherSuite) String() string {
+	switch m {
+	case MacsecCipherSuiteGCMAES128:
+		return "GCM-AES-128"
+	case MacsecCipherSuiteGCMAES256:
+		return "GCM-AES-256"
+	case MacsecCipherSuiteGCMAESXPN128:
+		return "GCM-AES-XPN-128"
+	case MacsecCipherSuiteGCMAESXPN256:
+		return "GCM-AES-XPN-256"
+	default:
+		return fmt.Sprintf("unknown MacsecCipherSuite value (%#x)", uint64(m))
+	}
+}
+
+const (
+	// GCM-AES-128, this is the default
+	MacsecCipherSuiteGCMAES128 MacsecCipherSuite = unix.MACSEC_CIPHER_ID_GCM_AES_128
+
+	// GCM-AES-256
+	MacsecCipherSuiteGCMAES256 MacsecCipherSuite = unix.MACSEC_CIPHER_ID_GCM_AES_256
+
+	// GCM-AES-128 with extended packet numbering
+	MacsecCipherSuiteGCMAESXPN128 MacsecCipherSuite = unix.MACSEC_CIPHER_ID_GCM_AES_XPN_128
+
+	// GCM-AES-256 with extended packet numbering
+	MacsecCipherSuiteGCMAESXPN256 MacsecCipherSuite = unix.MACSEC_CIPHER_ID_GCM_AES_XPN_256
+)
+
+// Macsec implements LinkDriver for the macsec driver
+type Macsec struct {
+	SCI           *uint64            // Specifies the secure channel identifier, conflicts with Port
+	Port          *uint16            // Specifies the port number used to derive the secure channel identifier
+	ICVLen        *uint8             // Specifies the length of the integrity check value
+	CipherSuite   *MacsecCipherSuite // Specifies the cipher suite
+	Window        *uint32            // Specifies the replay protection window, requires ReplayProtect
+	EncodingSA    *uint8             // Specifies the association number of the secure association used to send frames
+	Encrypt       *bool              // Specifies whether sent frames are encrypted
+	Protect       *bool              // Specifies whether sent frames are authenticated
+	IncSCI        *bool              // Specifies whether the secure channel identifier is included in sent frames
+	ES            *bool              // Specifies whether the end station bit is set in sent frames
+	SCB           *bool              // Specifies whether the single copy broadcast bit is set in sent frames
+	ReplayProtect *bool              // Specifies whether replay protection is enabled
+	Validation    *MacsecValidation  // Specifies how received frames are validated
+}
+
+var _ rtnetlink.LinkDriver = &Macsec{}
+
+func (m *Macsec) New() rtnetlink.LinkDriver {
+	return &Macsec{}
+}
+
+func (m *Macsec) Encode(ae *netlink.AttributeEncoder) error {
+	if m.SCI != nil {
+		encodeBE64(ae, unix.IFLA_MACSEC_SCI, *m.SCI)
+	}
+	if m.Port != nil {
+		encodeBE16(ae, unix.IFLA_MACSEC_PORT, *m.Port)
+	}
+	if m.ICVLen != nil {
+		ae.Uint8(unix.IFLA_MACSEC_ICV_LEN, *m.ICVLen)
+	}
+	if m.CipherSuite != nil {
+		ae.Uint64(unix.IFLA_MACSEC_CIPHER_SUITE, uint64(*m.CipherSuite))
+	}
+	if m.Window != nil {
+		ae.Uint32(unix.IFLA_MACSEC_WINDOW, *m.Window)
+	}
+	if m.EncodingSA != nil {
+		ae.Uint8(unix.IFLA_MACSEC_ENCODING_SA, *m.EncodingSA)
+	}
+	if m.Encrypt != nil {
+		ae.Uint8(unix.IFLA_MACSEC_ENCRYPT, boolToUint8(*m.Encrypt))
+	}
+	if m.Protect != nil {
+		ae.Uint8(unix.IFLA_MACSEC_PROTECT, boolToUint8(*m.Protect))
+	}
+	if m.IncSCI != nil {
+		ae.Uint8(unix.IFLA_MACSEC_INC_SCI, boolToUint8(*m.IncSCI))
+	}
+	if m.ES != nil {
+		ae.Uint8(unix.IFLA_MACSEC_ES, boolToUint8(*m.ES))
+	}
+	if m.SCB != nil {
+		ae.Uint8(unix.IFLA_MACSEC_SCB, boolToUint8(*m.SCB))
+	}
+	if m.ReplayProtect != nil {
+		ae.Uint8(unix.IFLA_MACSEC_REPLAY_PROTECT, boolToUint8(*m.ReplayProtect))
+	}
+	if m.Validation != nil {
+		ae.Uint8(unix.IFLA_MACSEC_VALIDATION, uint8(*m.Validation))
+	}
+	return nil
+}
+
+func (m *Macsec) Decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_MACSEC_SCI:
+			v, err := decodeBE64(ad)
+			if err != nil {
+				return err
+			}
+			m.SCI = &v
+		case unix.IFLA_MACSEC_PORT:
+			v, err := decodeBE16(ad)
+			if err != nil {
+				return err
+			}
+			m.Port = &v
+		case unix.IFLA_MACSEC_ICV_LEN:
+			v := ad.Uint8()
+			m.ICVLen = &v
+		case unix.IFLA_MACSEC_CIPHER_SUITE:
+			v := MacsecCipherSuite(ad.Uint64())
+			m.CipherSuite = &v
+		case unix.IFLA_MACSEC_WINDOW:
+			v := ad.Uint32()
+			m.Window = &v
+		case unix.IFLA_MACSEC_ENCODING_SA:
+			v := ad.Uint8()
+			m.EncodingSA = &v
+		case unix.IFLA_MACSEC_ENCRYPT:
+			v := ad.Uint8() != 0
+			m.Encrypt = &v
+		case unix.IFLA_MACSEC_PROTECT:
+			v := ad.Uint8() != 0
+			m.Protect = &v
+		case unix.IFLA_MACSEC_INC_SCI:
+			v := ad.Uint8() != 0
+			m.IncSCI = &v
+		case unix.IFLA_MACSEC_ES:
+			v := ad.Uint8() != 0
+			m.ES = &v
+		case unix.IFLA_MACSEC_SCB:
+			v := ad.Uint8() != 0
+			m.SCB = &v
+		case unix.IFLA_MACSEC_REPLAY_PROTECT:
+			v := ad.Uint8() != 0
+			m.ReplayProtect = &v
+		case unix.IFLA_MACSEC_VALIDATION:
+			v := MacsecValidation(ad.Uint8())
+			m.Validation = &v
+		}
+	}
+	return nil
+}
+
+func (*Macsec) Kind() string {
+	return "macsec"
+}
@@ -0,0 +1,162 @@
+package driver
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/mdlayher/netlink"
+)
+
+func TestMacsecRoundTrip(t *testing.T) {
+	var (
+		sci    uint64 = 0x0200_5e10_0001_0001
+		port   uint16 = 1
+		icvLen uint8  = 16
+		cipher        = MacsecCipherSuiteGCMAES256
+		window uint32 = 32
+		sa     uint8  = 1
+		yes           = true
+		no            = false
+		strict        = MacsecValidationStrict
+	)
+
+	tests := []struct {
+		name   string
+		macsec *Macsec
+	}{
+		{
+			name:   "empty",
+			macsec: &Macsec{},
+		},
+		{
+			name: "full",
+			macsec: &Macsec{
+				SCI:           &sci,
+				Port:          &port,
+				ICVLen:        &icvLen,
+				CipherSuite:   &cipher,
+				Window:        &window,
+				EncodingSA:    &sa,
+				Encrypt:       &yes,
+				Protect:       &yes,
+				IncSCI:        &yes,
+				ES:            &no,
+				SCB:           &no,
+				ReplayProtect: &yes,
+				Validation:    &strict,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundTrip(tt.macsec)
+			if err != nil {
+				t.Fatalf("failed to round trip macsec: %v", err)
+			}
+
+			if diff := cmp.Diff(rtnetlink.LinkDriver(tt.macsec), got); diff != "" {
+				t.Fatalf("unexpected macsec (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMacsecEncode(t *testing.T) {
+	var (
+		sci  uint64 = 0x0200_5e10_0001_0001
+		port uint16 = 1
+		yes         = true
+	)
+
+	tests := []struct {
+		name   string
+		macsec *Macsec
+		b      []byte
+	}{
+		{
+			name: "big endian sci",
+			macsec: &Macsec{
+				SCI: &sci,
+			},
+			b: []byte{
+				0x0c, 0x00, 0x01, 0x00,
+				0x02, 0x00, 0x5e, 0x10, 0x00, 0x01, 0x00, 0x01,
+			},
+		},
+		{
+			name: "big endian port",
+			macsec: &Macsec{
+				Port: &port,
+			},
+			b: []byte{
+				0x06, 0x00, 0x02, 0x00, 0x00, 0x01, 0x00, 0x00,
+			},
+		},
+		{
+			name: "boolean",
+			macsec: &Macsec{
+				Encrypt: &yes,
+			},
+			b: []byte{
+				0x05, 0x00, 0x07, 0x00, 0x01, 0x00, 0x00, 0x00,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ae := netlink.NewAttributeEncoder()
+			if err := tt.macsec.Encode(ae); err != nil {
+				t.Fatalf("failed to encode macsec: %v", err)
+			}
+			b, err := ae.Encode()
+			if err != nil {
+				t.Fatalf("failed to encode attributes: %v", err)
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("unexpected bytes:\n- want: [%# x]\n-  got: [%# x]", want, got)
+			}
+		})
+	}
+}
+
+func TestMacsecValidationString(t *testing.T) {
+	tests := []struct {
+		v MacsecValidation
+		s string
+	}{
+		{v: MacsecValidationDisabled, s: "disabled"},
+		{v: MacsecValidationCheck, s: "check"},
+		{v: MacsecValidationStrict, s: "strict"},
+		{v: 3, s: "unknown MacsecValidation value (3)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.v.String(); got != tt.s {
+			t.Errorf("unexpected string for validation %d: want %q, got %q", uint8(tt.v), tt.s, got)
+		}
+	}
+}
+
+func TestMacsecCipherSuiteString(t *testing.T) {
+	tests := []struct {
+		c MacsecCipherSuite
+		s string
+	}{
+		{c: MacsecCipherSuiteGCMAES128, s: "GCM-AES-128"},
+		{c: MacsecCipherSuiteGCMAES256, s: "GCM-AES-256"},
+		{c: MacsecCipherSuiteGCMAESXPN128, s: "GCM-AES-XPN-128"},
+		{c: MacsecCipherSuiteGCMAESXPN256, s: "GCM-AES-XPN-256"},
+		{c: 1, s: "unknown MacsecCipherSuite value (0x1)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.c.String(); got != tt.s {
+			t.Errorf("unexpected string for cipher suite %#x: want %q, got %q", uint64(tt.c), tt.s, got)
+		}
+	}
+}
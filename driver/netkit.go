@@ -53,6 +53,29 @@ const (
 	NetkitPolicyDrop NetkitPolicy = unix.NETKIT_DROP
 )
 
+// NetkitScrub specifies which packet metadata is cleared when a packet
+// crosses the netkit pair
+type NetkitScrub uint32
+
+func (n NetkitScrub) String() string {
+	switch n {
+	case NetkitScrubNone:
+		return "none"
+	case NetkitScrubDefault:
+		return "default"
+	default:
+		return fmt.Sprintf("unknown NetkitScrub value (%d)", n)
+	}
+}
+
+const (
+	// Packet metadata is kept
+	NetkitScrubNone NetkitScrub = unix.NETKIT_SCRUB_NONE
+
+	// Packet metadata such as the mark and priority is cleared, this is the default
+	NetkitScrubDefault NetkitScrub = unix.NETKIT_SCRUB_DEFAULT
+)
+
 // Netkit implements LinkDriverVerifier for the netkit driver
 type Netkit struct {
 	Mode       *NetkitMode            // Specifies driver operation mode
@@ -60,6 +83,10 @@ type Netkit struct {
 	PeerPolicy *NetkitPolicy          // Specifies default peer policy
 	Primary    bool                   // Shows primary link
 	PeerInfo   *rtnetlink.LinkMessage // Specifies peer link information
+	Scrub      *NetkitScrub           // Specifies the scrubbing of packets sent by the primary, set at creation only
+	PeerScrub  *NetkitScrub           // Specifies the scrubbing of packets sent by the peer, set at creation only
+	Headroom   *uint16                // Specifies the needed headroom of the devices
+	Tailroom   *uint16                // Specifies the needed tailroom of the devices
 }
 
 var _ rtnetlink.LinkDriverVerifier = &Netkit{}
@@ -89,6 +116,18 @@ func (n *Netkit) Decode(ad *netlink.AttributeDecoder) error {
 			n.PeerPolicy = &v
 		case unix.IFLA_NETKIT_PRIMARY:
 			n.Primary = ad.Uint8() != 0
+		case unix.IFLA_NETKIT_SCRUB:
+			v := NetkitScrub(ad.Uint32())
+			n.Scrub = &v
+		case unix.IFLA_NETKIT_PEER_SCRUB:
+			v := NetkitScrub(ad.Uint32())
+			n.PeerScrub = &v
+		case unix.IFLA_NETKIT_HEADROOM:
+			v := ad.Uint16()
+			n.Headroom = &v
+		case unix.IFLA_NETKIT_TAILROOM:
+			v := ad.Uint16()
+			n.Tailroom = &v
 		}
 	}
 	return nil
@@ -104,6 +143,18 @@ func (n *Netkit) Encode(ae *netlink.AttributeEncoder) error {
 	if n.PeerPolicy != nil {
 		ae.Int32(unix.IFLA_NETKIT_PEER_POLICY, int32(*n.PeerPolicy))
 	}
+	if n.Scrub != nil {
+		ae.Uint32(unix.IFLA_NETKIT_SCRUB, uint32(*n.Scrub))
+	}
+	if n.PeerScrub != nil {
+		ae.Uint32(unix.IFLA_NETKIT_PEER_SCRUB, uint32(*n.PeerScrub))
+	}
+	if n.Headroom != nil {
+		ae.Uint16(unix.IFLA_NETKIT_HEADROOM, *n.Headroom)
+	}
+	if n.Tailroom != nil {
+		ae.Uint16(unix.IFLA_NETKIT_TAILROOM, *n.Tailroom)
+	}
 	if n.PeerInfo != nil {
 		b, err := n.PeerInfo.MarshalBinary()
 		if err != nil {
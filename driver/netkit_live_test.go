@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/jsimonetti/rtnetlink/v2"
 	"github.com/jsimonetti/rtnetlink/v2/internal/testutils"
 	"github.com/mdlayher/netlink"
@@ -121,6 +122,10 @@ func TestNetkit(t *testing.T) {
 		},
 	}
 
+	// The scrub and headroom options are only reported by newer kernels, they
+	// are covered by TestNetkitDefaults.
+	ignore := cmpopts.IgnoreFields(Netkit{}, "Scrub", "PeerScrub", "Headroom", "Tailroom")
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if err := setupInterface(conn, tt.linkName, ifIndex, 0, tt.driver); err != nil {
@@ -132,7 +137,7 @@ func TestNetkit(t *testing.T) {
 			if err != nil {
 				t.Fatalf("failed to get primary netkit interface: %v", err)
 			}
-			if diff := cmp.Diff(tt.primary, msg.Attributes.Info.Data); diff != "" {
+			if diff := cmp.Diff(tt.primary, msg.Attributes.Info.Data, ignore); diff != "" {
 				t.Error(diff)
 			}
 
@@ -140,9 +145,80 @@ func TestNetkit(t *testing.T) {
 			if err != nil {
 				t.Fatalf("failed to get peer netkit interface: %v", err)
 			}
-			if diff := cmp.Diff(tt.peer, msg.Attributes.Info.Data); diff != "" {
+			if diff := cmp.Diff(tt.peer, msg.Attributes.Info.Data, ignore); diff != "" {
 				t.Error(diff)
 			}
 		})
 	}
 }
+
+func TestNetkitDefaults(t *testing.T) {
+	testutils.SkipOnOldKernel(t, "6.14", "netkit headroom and tailroom reporting")
+
+	conn, err := rtnetlink.Dial(nil)
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	const (
+		ifIndex     = 1013
+		ifPeerIndex = 1014
+	)
+
+	driver := &Netkit{
+		PeerInfo: &rtnetlink.LinkMessage{
+			Index: ifPeerIndex,
+		},
+	}
+	if err := setupInterface(conn, "", ifIndex, 0, driver); err != nil {
+		t.Fatalf("failed to setup netkit interface: %v", err)
+	}
+	defer conn.Link.Delete(ifIndex)
+
+	var (
+		modeL3  = NetkitModeL3
+		polPass = NetkitPolicyPass
+		scrub   = NetkitScrubDefault
+		room    uint16
+	)
+
+	for _, want := range []struct {
+		index  uint32
+		netkit *Netkit
+	}{
+		{
+			index: ifIndex,
+			netkit: &Netkit{
+				Mode:       &modeL3,
+				Policy:     &polPass,
+				PeerPolicy: &polPass,
+				Primary:    true,
+				Scrub:      &scrub,
+				PeerScrub:  &scrub,
+				Headroom:   &room,
+				Tailroom:   &room,
+			},
+		},
+		{
+			index: ifPeerIndex,
+			netkit: &Netkit{
+				Mode:       &modeL3,
+				Policy:     &polPass,
+				PeerPolicy: &polPass,
+				Scrub:      &scrub,
+				PeerScrub:  &scrub,
+				Headroom:   &room,
+				Tailroom:   &room,
+			},
+		},
+	} {
+		msg, err := getInterface(conn, want.index)
+		if err != nil {
+			t.Fatalf("failed to get netkit interface %d: %v", want.index, err)
+		}
+		if diff := cmp.Diff(want.netkit, msg.Attributes.Info.Data); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
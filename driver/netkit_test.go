@@ -0,0 +1,99 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+func TestNetkitRoundTrip(t *testing.T) {
+	var (
+		mode     = NetkitModeL2
+		policy   = NetkitPolicyDrop
+		none     = NetkitScrubNone
+		def      = NetkitScrubDefault
+		headroom uint16
+		tailroom uint16 = 64
+	)
+
+	tests := []struct {
+		name   string
+		netkit *Netkit
+	}{
+		{
+			name:   "empty",
+			netkit: &Netkit{},
+		},
+		{
+			name: "full",
+			netkit: &Netkit{
+				Mode:       &mode,
+				Policy:     &policy,
+				PeerPolicy: &policy,
+				Scrub:      &none,
+				PeerScrub:  &def,
+				Headroom:   &headroom,
+				Tailroom:   &tailroom,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundTrip(tt.netkit)
+			if err != nil {
+				t.Fatalf("failed to round trip netkit: %v", err)
+			}
+
+			if diff := cmp.Diff(rtnetlink.LinkDriver(tt.netkit), got); diff != "" {
+				t.Fatalf("unexpected netkit (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNetkitEncodeUnset(t *testing.T) {
+	mode := NetkitModeL3
+
+	ae := netlink.NewAttributeEncoder()
+	if err := (&Netkit{Mode: &mode}).Encode(ae); err != nil {
+		t.Fatalf("failed to encode netkit: %v", err)
+	}
+	b, err := ae.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode attributes: %v", err)
+	}
+
+	ad, err := netlink.NewAttributeDecoder(b)
+	if err != nil {
+		t.Fatalf("failed to create attribute decoder: %v", err)
+	}
+	var types []uint16
+	for ad.Next() {
+		types = append(types, ad.Type())
+	}
+
+	if diff := cmp.Diff([]uint16{unix.IFLA_NETKIT_MODE}, types); diff != "" {
+		t.Fatalf("unexpected attribute types (-want +got):\n%s", diff)
+	}
+}
+
+func TestNetkitScrubString(t *testing.T) {
+	tests := []struct {
+		m NetkitScrub
+		s string
+	}{
+		{m: NetkitScrubNone, s: "none"},
+		{m: NetkitScrubDefault, s: "default"},
+		{m: 2, s: "unknown NetkitScrub value (2)"},
+	}
+
+	for _, tt := range tests {
+		if want, got := tt.s, tt.m.String(); want != got {
+			t.Fatalf("unexpected string, want: %q, got: %q", want, got)
+		}
+	}
+}
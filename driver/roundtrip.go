@@ -0,0 +1,38 @@
+package driver
+
+import (
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/mdlayher/netlink"
+)
+
+// RoundTrip encodes d into netlink attributes and decodes them again into a
+// new instance of the same driver, as created by d.New. It allows drivers,
+// including custom ones, to verify that their Encode and Decode functions
+// agree with each other.
+//
+// Read only values are not encoded by most drivers and will therefore not be
+// present in the returned driver.
+func RoundTrip(d rtnetlink.LinkDriver) (rtnetlink.LinkDriver, error) {
+	ae := netlink.NewAttributeEncoder()
+	if err := d.Encode(ae); err != nil {
+		return nil, err
+	}
+	b, err := ae.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	ad, err := netlink.NewAttributeDecoder(b)
+	if err != nil {
+		return nil, err
+	}
+	out := d.New()
+	if err := out.Decode(ad); err != nil {
+		return nil, err
+	}
+	if err := ad.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,72 @@
+package driver
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+)
+
+func TestRoundTrip(t *testing.T) {
+	var (
+		u32100 uint32 = 100
+		lacp          = BondLacpRateFast
+		l2            = NetkitModeL2
+		drop          = NetkitPolicyDrop
+	)
+
+	tests := []struct {
+		name string
+		d    rtnetlink.LinkDriver
+		want rtnetlink.LinkDriver
+	}{
+		{
+			name: "bond",
+			d: &Bond{
+				Mode:         BondMode802_3AD,
+				Miimon:       &u32100,
+				AdLacpRate:   &lacp,
+				ArpIpTargets: []net.IP{{192, 0, 2, 1}, {192, 0, 2, 2}},
+			},
+			want: &Bond{
+				Mode:         BondMode802_3AD,
+				Miimon:       &u32100,
+				AdLacpRate:   &lacp,
+				ArpIpTargets: []net.IP{{192, 0, 2, 1}, {192, 0, 2, 2}},
+			},
+		},
+		{
+			name: "netkit",
+			d: &Netkit{
+				Mode:       &l2,
+				Policy:     &drop,
+				PeerPolicy: &drop,
+				PeerInfo:   &rtnetlink.LinkMessage{},
+			},
+			// the peer information is not reported back by the kernel
+			want: &Netkit{
+				Mode:       &l2,
+				Policy:     &drop,
+				PeerPolicy: &drop,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundTrip(tt.d)
+			if err != nil {
+				t.Fatalf("failed to round trip driver: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected driver (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := RoundTrip(&Bond{ArpIpTargets: []net.IP{net.ParseIP("2001:db8::1")}}); err == nil {
+		t.Fatal("expected an error for an invalid driver, but none occurred")
+	}
+}
@@ -0,0 +1,131 @@
+package driver
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+// Sit implements LinkDriverVerifier for the sit driver
+type Sit struct {
+	// The IFLA_IPTUN_* attributes shared with the ipip driver
+	Ipip
+
+	// Specifies the IPv6 rapid deployment (6rd) configuration of the tunnel
+	Sixrd *Sixrd
+}
+
+// Sixrd contains the IPv6 rapid deployment (6rd) configuration of a sit tunnel
+type Sixrd struct {
+	// Specifies the IPv6 prefix of the 6rd domain
+	Prefix *net.IPNet
+
+	// Specifies the IPv4 prefix common to all 6rd relays of the domain
+	RelayPrefix *net.IPNet
+}
+
+var _ rtnetlink.LinkDriverVerifier = &Sit{}
+
+func (s *Sit) New() rtnetlink.LinkDriver {
+	return &Sit{}
+}
+
+func (s *Sit) Verify(msg *rtnetlink.LinkMessage) error {
+	if err := verifyIPv4Endpoints(s.Kind(), s.Local, s.Remote); err != nil {
+		return err
+	}
+	if s.Sixrd != nil {
+		return s.Sixrd.verify()
+	}
+	return nil
+}
+
+func (s *Sit) Encode(ae *netlink.AttributeEncoder) error {
+	s.Ipip.encode(ae)
+	if s.Sixrd != nil {
+		s.Sixrd.encode(ae)
+	}
+	return nil
+}
+
+func (s *Sit) Decode(ad *netlink.AttributeDecoder) error {
+	var (
+		sixrd                     Sixrd
+		hasSixrd                  bool
+		prefix, relayPrefix       net.IP
+		prefixLen, relayPrefixLen uint16
+	)
+	for ad.Next() {
+		if s.Ipip.decodeAttr(ad) {
+			continue
+		}
+		switch ad.Type() {
+		case unix.IFLA_IPTUN_6RD_PREFIX:
+			prefix = ad.Bytes()
+		case unix.IFLA_IPTUN_6RD_PREFIXLEN:
+			prefixLen = ad.Uint16()
+		case unix.IFLA_IPTUN_6RD_RELAY_PREFIX:
+			relayPrefix = ad.Bytes()
+		case unix.IFLA_IPTUN_6RD_RELAY_PREFIXLEN:
+			relayPrefixLen = ad.Uint16()
+		default:
+			continue
+		}
+		hasSixrd = true
+	}
+	if !hasSixrd {
+		return nil
+	}
+	if prefix != nil {
+		sixrd.Prefix = &net.IPNet{IP: prefix, Mask: net.CIDRMask(int(prefixLen), 8*net.IPv6len)}
+	}
+	if relayPrefix != nil {
+		sixrd.RelayPrefix = &net.IPNet{IP: relayPrefix, Mask: net.CIDRMask(int(relayPrefixLen), 8*net.IPv4len)}
+	}
+	s.Sixrd = &sixrd
+	return nil
+}
+
+func (*Sit) Kind() string {
+	return "sit"
+}
+
+func (s *Sixrd) encode(ae *netlink.AttributeEncoder) {
+	if s.Prefix != nil {
+		ones, _ := s.Prefix.Mask.Size()
+		ae.Bytes(unix.IFLA_IPTUN_6RD_PREFIX, s.Prefix.IP.To16())
+		ae.Uint16(unix.IFLA_IPTUN_6RD_PREFIXLEN, uint16(ones))
+	}
+	if s.RelayPrefix != nil {
+		ones, _ := s.RelayPrefix.Mask.Size()
+		ae.Bytes(unix.IFLA_IPTUN_6RD_RELAY_PREFIX, s.RelayPrefix.IP.To4())
+		ae.Uint16(unix.IFLA_IPTUN_6RD_RELAY_PREFIXLEN, uint16(ones))
+	}
+}
+
+func (s *Sixrd) verify() error {
+	var prefixLen, relayPrefixLen int
+	if p := s.Prefix; p != nil {
+		ones, bits := p.Mask.Size()
+		if p.IP.To4() != nil || p.IP.To16() == nil || bits != 8*net.IPv6len {
+			return fmt.Errorf("6rd prefix %s is not an IPv6 prefix", p)
+		}
+		prefixLen = ones
+	}
+	if p := s.RelayPrefix; p != nil {
+		ones, bits := p.Mask.Size()
+		if p.IP.To4() == nil || bits != 8*net.IPv4len {
+			return fmt.Errorf("6rd relay prefix %s is not an IPv4 prefix", p)
+		}
+		relayPrefixLen = ones
+	}
+	// The IPv4 address bits not covered by the relay prefix are embedded
+	// after the 6rd prefix, which must leave room for a /64.
+	if n := prefixLen + 8*net.IPv4len - relayPrefixLen; n > 64 {
+		return fmt.Errorf("6rd delegated prefix length %d exceeds 64", n)
+	}
+	return nil
+}
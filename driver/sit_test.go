@@ -0,0 +1,154 @@
+package driver
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+)
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	t.Helper()
+
+	ip, ipn, err := net.ParseCIDR(s)
+	if err != nil {
+		t.Fatalf("failed to parse CIDR %q: %v", s, err)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ipn.IP = ip4
+	} else {
+		ipn.IP = ip.To16()
+	}
+	return ipn
+}
+
+func TestSitRoundTrip(t *testing.T) {
+	var ttl uint8 = 64
+
+	tests := []struct {
+		name string
+		sit  *Sit
+	}{
+		{
+			name: "empty",
+			sit:  &Sit{},
+		},
+		{
+			name: "endpoints",
+			sit: &Sit{
+				Ipip: Ipip{
+					Local:  net.IPv4(192, 0, 2, 1).To4(),
+					Remote: net.IPv4(192, 0, 2, 2).To4(),
+					TTL:    &ttl,
+				},
+			},
+		},
+		{
+			name: "6rd",
+			sit: &Sit{
+				Ipip: Ipip{
+					Local: net.IPv4(192, 0, 2, 1).To4(),
+				},
+				Sixrd: &Sixrd{
+					Prefix:      mustParseCIDR(t, "2001:db8::/32"),
+					RelayPrefix: mustParseCIDR(t, "192.0.0.0/8"),
+				},
+			},
+		},
+		{
+			name: "6rd without relay prefix",
+			sit: &Sit{
+				Sixrd: &Sixrd{
+					Prefix: mustParseCIDR(t, "2001:db8::/32"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundTrip(tt.sit)
+			if err != nil {
+				t.Fatalf("failed to round trip sit: %v", err)
+			}
+
+			if diff := cmp.Diff(rtnetlink.LinkDriver(tt.sit), got); diff != "" {
+				t.Fatalf("unexpected sit (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSitVerify(t *testing.T) {
+	tests := []struct {
+		name string
+		sit  *Sit
+		ok   bool
+	}{
+		{
+			name: "IPv4 endpoints",
+			sit: &Sit{
+				Ipip: Ipip{
+					Local:  net.IPv4(192, 0, 2, 1),
+					Remote: net.IPv4(192, 0, 2, 2),
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "IPv6 remote",
+			sit: &Sit{
+				Ipip: Ipip{
+					Remote: net.ParseIP("2001:db8::1"),
+				},
+			},
+		},
+		{
+			name: "6rd",
+			sit: &Sit{
+				Sixrd: &Sixrd{
+					Prefix:      mustParseCIDR(t, "2001:db8::/32"),
+					RelayPrefix: mustParseCIDR(t, "192.0.0.0/8"),
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "6rd IPv4 prefix",
+			sit: &Sit{
+				Sixrd: &Sixrd{
+					Prefix: mustParseCIDR(t, "192.0.2.0/24"),
+				},
+			},
+		},
+		{
+			name: "6rd IPv6 relay prefix",
+			sit: &Sit{
+				Sixrd: &Sixrd{
+					RelayPrefix: mustParseCIDR(t, "2001:db8::/32"),
+				},
+			},
+		},
+		{
+			name: "6rd delegated prefix too long",
+			sit: &Sit{
+				Sixrd: &Sixrd{
+					Prefix: mustParseCIDR(t, "2001:db8::/48"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sit.Verify(&rtnetlink.LinkMessage{})
+			if tt.ok && err != nil {
+				t.Fatalf("failed to verify sit: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
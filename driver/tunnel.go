@@ -0,0 +1,133 @@
+package driver
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/mdlayher/netlink"
+)
+
+// TunnelEncap specifies the UDP encapsulation used by an IP tunnel
+type TunnelEncap uint16
+
+const (
+	// No UDP encapsulation
+	TunnelEncapNone TunnelEncap = iota
+
+	// Foo-over-UDP encapsulation
+	TunnelEncapFOU
+
+	// Generic UDP encapsulation
+	TunnelEncapGUE
+
+	// MPLS encapsulation
+	TunnelEncapMPLS
+)
+
+func (t TunnelEncap) String() string {
+	switch t {
+	case TunnelEncapNone:
+		return "none"
+	case TunnelEncapFOU:
+		return "fou"
+	case TunnelEncapGUE:
+		return "gue"
+	case TunnelEncapMPLS:
+		return "mpls"
+	default:
+		return fmt.Sprintf("unknown TunnelEncap value (%d)", t)
+	}
+}
+
+// TunnelEncapFlags specifies options of the UDP encapsulation of an IP tunnel
+type TunnelEncapFlags uint16
+
+const (
+	// Enable the UDP checksum for IPv4
+	TunnelEncapFlagCsum TunnelEncapFlags = 1 << iota
+
+	// Enable the UDP checksum for IPv6
+	TunnelEncapFlagCsum6
+
+	// Enable remote checksum offload
+	TunnelEncapFlagRemCsum
+)
+
+// encodeBE16 encodes v as a big endian (network byte order) attribute.
+func encodeBE16(ae *netlink.AttributeEncoder, typ uint16, v uint16) {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+	ae.Bytes(typ, b)
+}
+
+// encodeBE32 encodes v as a big endian (network byte order) attribute.
+func encodeBE32(ae *netlink.AttributeEncoder, typ uint16, v uint32) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	ae.Bytes(typ, b)
+}
+
+// encodeBE64 encodes v as a big endian (network byte order) attribute.
+func encodeBE64(ae *netlink.AttributeEncoder, typ uint16, v uint64) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	ae.Bytes(typ, b)
+}
+
+// decodeBE16 decodes a big endian (network byte order) attribute.
+func decodeBE16(ad *netlink.AttributeDecoder) (uint16, error) {
+	b := ad.Bytes()
+	if len(b) != 2 {
+		return 0, fmt.Errorf("invalid length %d for big endian uint16 attribute %d", len(b), ad.Type())
+	}
+	return binary.BigEndian.Uint16(b), nil
+}
+
+// decodeBE32 decodes a big endian (network byte order) attribute.
+func decodeBE32(ad *netlink.AttributeDecoder) (uint32, error) {
+	b := ad.Bytes()
+	if len(b) != 4 {
+		return 0, fmt.Errorf("invalid length %d for big endian uint32 attribute %d", len(b), ad.Type())
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+// decodeBE64 decodes a big endian (network byte order) attribute.
+func decodeBE64(ad *netlink.AttributeDecoder) (uint64, error) {
+	b := ad.Bytes()
+	if len(b) != 8 {
+		return 0, fmt.Errorf("invalid length %d for big endian uint64 attribute %d", len(b), ad.Type())
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// boolToUint8 converts a boolean option to the uint8 the kernel expects.
+func boolToUint8(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// verifyIPv4Endpoints checks that the tunnel endpoints, if set, are IPv4
+// addresses.
+func verifyIPv4Endpoints(kind string, local, remote net.IP) error {
+	for _, ip := range []net.IP{local, remote} {
+		if ip != nil && ip.To4() == nil {
+			return fmt.Errorf("%s tunnel endpoint %s is not an IPv4 address", kind, ip)
+		}
+	}
+	return nil
+}
+
+// verifyIPv6Endpoints checks that the tunnel endpoints, if set, are IPv6
+// addresses.
+func verifyIPv6Endpoints(kind string, local, remote net.IP) error {
+	for _, ip := range []net.IP{local, remote} {
+		if ip != nil && (ip.To16() == nil || ip.To4() != nil) {
+			return fmt.Errorf("%s tunnel endpoint %s is not an IPv6 address", kind, ip)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package driver
+
+import (
+	"fmt"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+// TuntapMode specifies whether a tun device carries IP packets or Ethernet frames
+type TuntapMode uint8
+
+func (t TuntapMode) String() string {
+	switch t {
+	case TuntapModeTun:
+		return "tun"
+	case TuntapModeTap:
+		return "tap"
+	default:
+		return fmt.Sprintf("unknown TuntapMode value (%d)", t)
+	}
+}
+
+const (
+	// The device carries IP packets
+	TuntapModeTun TuntapMode = unix.IFF_TUN
+
+	// The device carries Ethernet frames
+	TuntapModeTap TuntapMode = unix.IFF_TAP
+)
+
+// tun_no_owner is reported by the kernel for devices without owner or group.
+const tun_no_owner = 0xffffffff
+
+// Tuntap implements LinkDriver for the tun driver, which provides both tun and
+// tap devices.
+//
+// The kernel does not support creating tun devices through rtnetlink, they are
+// created through /dev/net/tun instead. This driver mostly serves to decode
+// the attributes of existing devices.
+type Tuntap struct {
+	Mode              *TuntapMode // Specifies whether the device is a tun or tap device
+	Owner             *uint32     // Specifies the user owning the device, nil if unset
+	Group             *uint32     // Specifies the group owning the device, nil if unset
+	PI                *bool       // Specifies whether packets are prefixed with packet information
+	VnetHdr           *bool       // Specifies whether packets are prefixed with a virtio net header
+	Persist           *bool       // Specifies whether the device outlives the process that created it
+	MultiQueue        *bool       // Specifies whether the device supports multiple queues
+	NumQueues         *uint32     // Shows the number of queues of a multi queue device (read only)
+	NumDisabledQueues *uint32     // Shows the number of disabled queues of a multi queue device (read only)
+}
+
+var _ rtnetlink.LinkDriver = &Tuntap{}
+
+func (t *Tuntap) New() rtnetlink.LinkDriver {
+	return &Tuntap{}
+}
+
+func (t *Tuntap) Encode(ae *netlink.AttributeEncoder) error {
+	if t.Owner != nil {
+		ae.Uint32(unix.IFLA_TUN_OWNER, *t.Owner)
+	}
+	if t.Group != nil {
+		ae.Uint32(unix.IFLA_TUN_GROUP, *t.Group)
+	}
+	if t.Mode != nil {
+		ae.Uint8(unix.IFLA_TUN_TYPE, uint8(*t.Mode))
+	}
+	if t.PI != nil {
+		ae.Uint8(unix.IFLA_TUN_PI, boolToUint8(*t.PI))
+	}
+	if t.VnetHdr != nil {
+		ae.Uint8(unix.IFLA_TUN_VNET_HDR, boolToUint8(*t.VnetHdr))
+	}
+	if t.Persist != nil {
+		ae.Uint8(unix.IFLA_TUN_PERSIST, boolToUint8(*t.Persist))
+	}
+	if t.MultiQueue != nil {
+		ae.Uint8(unix.IFLA_TUN_MULTI_QUEUE, boolToUint8(*t.MultiQueue))
+	}
+	return nil
+}
+
+func (t *Tuntap) Decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_TUN_OWNER:
+			if v := ad.Uint32(); v != tun_no_owner {
+				t.Owner = &v
+			}
+		case unix.IFLA_TUN_GROUP:
+			if v := ad.Uint32(); v != tun_no_owner {
+				t.Group = &v
+			}
+		case unix.IFLA_TUN_TYPE:
+			v := TuntapMode(ad.Uint8())
+			t.Mode = &v
+		case unix.IFLA_TUN_PI:
+			v := ad.Uint8() != 0
+			t.PI = &v
+		case unix.IFLA_TUN_VNET_HDR:
+			v := ad.Uint8() != 0
+			t.VnetHdr = &v
+		case unix.IFLA_TUN_PERSIST:
+			v := ad.Uint8() != 0
+			t.Persist = &v
+		case unix.IFLA_TUN_MULTI_QUEUE:
+			v := ad.Uint8() != 0
+			t.MultiQueue = &v
+		case unix.IFLA_TUN_NUM_QUEUES:
+			v := ad.Uint32()
+			t.NumQueues = &v
+		case unix.IFLA_TUN_NUM_DISABLED_QUEUES:
+			v := ad.Uint32()
+			t.NumDisabledQueues = &v
+		}
+	}
+	return nil
+}
+
+func (*Tuntap) Kind() string {
+	return "tun"
+}
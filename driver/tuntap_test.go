@@ -0,0 +1,114 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/mdlayher/netlink"
+)
+
+func TestTuntapRoundTrip(t *testing.T) {
+	var (
+		tap          = TuntapModeTap
+		owner uint32 = 1000
+		group uint32 = 100
+		yes          = true
+		no           = false
+	)
+
+	tests := []struct {
+		name   string
+		tuntap *Tuntap
+	}{
+		{
+			name:   "empty",
+			tuntap: &Tuntap{},
+		},
+		{
+			name: "full",
+			tuntap: &Tuntap{
+				Mode:       &tap,
+				Owner:      &owner,
+				Group:      &group,
+				PI:         &no,
+				VnetHdr:    &yes,
+				Persist:    &yes,
+				MultiQueue: &no,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundTrip(tt.tuntap)
+			if err != nil {
+				t.Fatalf("failed to round trip tuntap: %v", err)
+			}
+
+			if diff := cmp.Diff(rtnetlink.LinkDriver(tt.tuntap), got); diff != "" {
+				t.Fatalf("unexpected tuntap (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTuntapDecode(t *testing.T) {
+	var (
+		tun           = TuntapModeTun
+		yes           = true
+		queues uint32 = 4
+		none   uint32
+	)
+
+	// The kernel reports -1 for a device without owner and group, and only
+	// reports the queues of multi queue devices.
+	ae := netlink.NewAttributeEncoder()
+	ae.Uint32(0x1, tun_no_owner)
+	ae.Uint32(0x2, tun_no_owner)
+	ae.Uint8(0x3, uint8(tun))
+	ae.Uint8(0x7, 1)
+	ae.Uint32(0x8, queues)
+	ae.Uint32(0x9, none)
+	b, err := ae.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode attributes: %v", err)
+	}
+
+	ad, err := netlink.NewAttributeDecoder(b)
+	if err != nil {
+		t.Fatalf("failed to create attribute decoder: %v", err)
+	}
+
+	var got Tuntap
+	if err := got.Decode(ad); err != nil {
+		t.Fatalf("failed to decode tuntap: %v", err)
+	}
+
+	want := Tuntap{
+		Mode:              &tun,
+		MultiQueue:        &yes,
+		NumQueues:         &queues,
+		NumDisabledQueues: &none,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected tuntap (-want +got):\n%s", diff)
+	}
+}
+
+func TestTuntapModeString(t *testing.T) {
+	tests := []struct {
+		m TuntapMode
+		s string
+	}{
+		{m: TuntapModeTun, s: "tun"},
+		{m: TuntapModeTap, s: "tap"},
+		{m: 3, s: "unknown TuntapMode value (3)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.s {
+			t.Errorf("unexpected string for mode %d: want %q, got %q", uint8(tt.m), tt.s, got)
+		}
+	}
+}
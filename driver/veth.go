@@ -1,8 +1,6 @@
 package driver
 
 import (
-	"fmt"
-
 	"github.com/jsimonetti/rtnetlink/v2"
 	"github.com/mdlayher/netlink"
 )
@@ -16,6 +14,8 @@ type Veth struct {
 
 var _ rtnetlink.LinkDriverVerifier = &Veth{}
 
+var _ rtnetlink.LinkDriverCapabilities = &Veth{}
+
 func (v *Veth) New() rtnetlink.LinkDriver {
 	return &Veth{}
 }
@@ -38,14 +38,18 @@ func (*Veth) Kind() string {
 	return "veth"
 }
 
-const (
-	eth_min_mtu = 68    // Min IPv4 MTU per RFC791
-	eth_max_mtu = 65535 // 65535, same as IP_MAX_MTU
-)
-
 func (v *Veth) Verify(msg *rtnetlink.LinkMessage) error {
-	if msg.Attributes != nil && msg.Attributes.MTU > 0 && (msg.Attributes.MTU < eth_min_mtu || msg.Attributes.MTU > eth_max_mtu) {
-		return fmt.Errorf("invalid MTU value %d, must be between %d %d", msg.Attributes.MTU, eth_min_mtu, eth_max_mtu)
-	}
-	return nil
+	return verifyMTU(msg, eth_min_mtu, eth_max_mtu)
+}
+
+// CanBeSlave implements rtnetlink.LinkDriverCapabilities, a veth link can be
+// enslaved to a bond, bridge or vrf
+func (*Veth) CanBeSlave() bool {
+	return true
+}
+
+// CanHaveSlaves implements rtnetlink.LinkDriverCapabilities, a veth link
+// cannot be a master
+func (*Veth) CanHaveSlaves() bool {
+	return false
 }
@@ -0,0 +1,46 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+)
+
+func TestVethVerify(t *testing.T) {
+	tests := []struct {
+		name string
+		mtu  uint32
+		ok   bool
+	}{
+		{name: "unset", ok: true},
+		{name: "too small", mtu: 67},
+		{name: "too large", mtu: 65536},
+		{name: "min", mtu: 68, ok: true},
+		{name: "max", mtu: 65535, ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &rtnetlink.LinkMessage{
+				Attributes: &rtnetlink.LinkAttributes{
+					MTU: tt.mtu,
+				},
+			}
+
+			err := (&Veth{}).Verify(msg)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to verify MTU %d: %v", tt.mtu, err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error for MTU %d, but none occurred", tt.mtu)
+			}
+		})
+	}
+}
+
+func TestVethCapabilities(t *testing.T) {
+	v := &Veth{}
+	if !v.CanBeSlave() || v.CanHaveSlaves() {
+		t.Fatal("a veth link must be enslaveable and must not have slaves")
+	}
+}
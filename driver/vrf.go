@@ -0,0 +1,49 @@
+package driver
+
+import (
+	"errors"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+// Vrf implements LinkDriverVerifier for the vrf driver
+type Vrf struct {
+	Table *uint32 // Specifies the routing table of the VRF, required
+}
+
+var _ rtnetlink.LinkDriverVerifier = &Vrf{}
+
+func (v *Vrf) New() rtnetlink.LinkDriver {
+	return &Vrf{}
+}
+
+func (v *Vrf) Verify(msg *rtnetlink.LinkMessage) error {
+	if v.Table == nil {
+		return errors.New("vrf requires a routing table")
+	}
+	return nil
+}
+
+func (v *Vrf) Encode(ae *netlink.AttributeEncoder) error {
+	if v.Table != nil {
+		ae.Uint32(unix.IFLA_VRF_TABLE, *v.Table)
+	}
+	return nil
+}
+
+func (v *Vrf) Decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_VRF_TABLE:
+			t := ad.Uint32()
+			v.Table = &t
+		}
+	}
+	return nil
+}
+
+func (*Vrf) Kind() string {
+	return "vrf"
+}
@@ -0,0 +1,54 @@
+package driver
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/mdlayher/netlink"
+)
+
+func TestVrfRoundTrip(t *testing.T) {
+	table := uint32(100)
+
+	for _, vrf := range []*Vrf{{}, {Table: &table}} {
+		got, err := RoundTrip(vrf)
+		if err != nil {
+			t.Fatalf("failed to round trip vrf: %v", err)
+		}
+
+		if diff := cmp.Diff(rtnetlink.LinkDriver(vrf), got); diff != "" {
+			t.Fatalf("unexpected vrf (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestVrfEncode(t *testing.T) {
+	table := uint32(100)
+
+	ae := netlink.NewAttributeEncoder()
+	if err := (&Vrf{Table: &table}).Encode(ae); err != nil {
+		t.Fatalf("failed to encode vrf: %v", err)
+	}
+	b, err := ae.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode attributes: %v", err)
+	}
+
+	want := []byte{0x08, 0x00, 0x01, 0x00, 0x64, 0x00, 0x00, 0x00}
+	if !bytes.Equal(want, b) {
+		t.Fatalf("unexpected bytes:\n- want: [%# x]\n-  got: [%# x]", want, b)
+	}
+}
+
+func TestVrfVerify(t *testing.T) {
+	table := uint32(100)
+
+	if err := (&Vrf{}).Verify(&rtnetlink.LinkMessage{}); err == nil {
+		t.Fatal("expected an error for a vrf without table, but none occurred")
+	}
+	if err := (&Vrf{Table: &table}).Verify(&rtnetlink.LinkMessage{}); err != nil {
+		t.Fatalf("failed to verify vrf: %v", err)
+	}
+}
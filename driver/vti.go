@@ -0,0 +1,150 @@
+package driver
+
+import (
+	"net"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+// Vti implements LinkDriverVerifier for the vti driver
+type Vti struct {
+	// Specifies the local address of the tunnel, IPv4 for vti and IPv6 for vti6
+	Local net.IP
+
+	// Specifies the remote address of the tunnel, IPv4 for vti and IPv6 for vti6
+	Remote net.IP
+
+	// Specifies the index of the underlying device used for the tunnel
+	Link *uint32
+
+	// Specifies the key used to match the IPsec policies of received packets
+	IKey *uint32
+
+	// Specifies the key used to match the IPsec policies of sent packets
+	OKey *uint32
+
+	// Specifies the firewall mark used for the IPsec policy lookup
+	FwMark *uint32
+}
+
+var _ rtnetlink.LinkDriverVerifier = &Vti{}
+
+func (v *Vti) New() rtnetlink.LinkDriver {
+	return &Vti{}
+}
+
+func (v *Vti) Verify(msg *rtnetlink.LinkMessage) error {
+	return v.verify(v.Kind(), unix.AF_INET)
+}
+
+func (v *Vti) Encode(ae *netlink.AttributeEncoder) error {
+	v.encode(ae, unix.AF_INET)
+	return nil
+}
+
+func (v *Vti) Decode(ad *netlink.AttributeDecoder) error {
+	return v.decode(ad)
+}
+
+func (*Vti) Kind() string {
+	return "vti"
+}
+
+// Vti6 implements LinkDriverVerifier for the vti6 driver, it has the same
+// options as Vti but uses IPv6 endpoints
+type Vti6 Vti
+
+var _ rtnetlink.LinkDriverVerifier = &Vti6{}
+
+func (v *Vti6) New() rtnetlink.LinkDriver {
+	return &Vti6{}
+}
+
+func (v *Vti6) Verify(msg *rtnetlink.LinkMessage) error {
+	return (*Vti)(v).verify(v.Kind(), unix.AF_INET6)
+}
+
+func (v *Vti6) Encode(ae *netlink.AttributeEncoder) error {
+	(*Vti)(v).encode(ae, unix.AF_INET6)
+	return nil
+}
+
+func (v *Vti6) Decode(ad *netlink.AttributeDecoder) error {
+	return (*Vti)(v).decode(ad)
+}
+
+func (*Vti6) Kind() string {
+	return "vti6"
+}
+
+// verify checks that the endpoints of the tunnel belong to family.
+func (v *Vti) verify(kind string, family int) error {
+	if family == unix.AF_INET6 {
+		return verifyIPv6Endpoints(kind, v.Local, v.Remote)
+	}
+	return verifyIPv4Endpoints(kind, v.Local, v.Remote)
+}
+
+// encode encodes the IFLA_VTI_* attributes, with the endpoints in the
+// address format of family.
+func (v *Vti) encode(ae *netlink.AttributeEncoder, family int) {
+	ip := func(ip net.IP) net.IP {
+		if family == unix.AF_INET6 {
+			return ip.To16()
+		}
+		return ip.To4()
+	}
+
+	if v.Link != nil {
+		ae.Uint32(unix.IFLA_VTI_LINK, *v.Link)
+	}
+	if v.IKey != nil {
+		encodeBE32(ae, unix.IFLA_VTI_IKEY, *v.IKey)
+	}
+	if v.OKey != nil {
+		encodeBE32(ae, unix.IFLA_VTI_OKEY, *v.OKey)
+	}
+	if v.Local != nil {
+		ae.Bytes(unix.IFLA_VTI_LOCAL, ip(v.Local))
+	}
+	if v.Remote != nil {
+		ae.Bytes(unix.IFLA_VTI_REMOTE, ip(v.Remote))
+	}
+	if v.FwMark != nil {
+		ae.Uint32(unix.IFLA_VTI_FWMARK, *v.FwMark)
+	}
+}
+
+// decode decodes the IFLA_VTI_* attributes, the endpoints are kept in the
+// address format reported by the kernel.
+func (v *Vti) decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_VTI_LINK:
+			l := ad.Uint32()
+			v.Link = &l
+		case unix.IFLA_VTI_IKEY:
+			k, err := decodeBE32(ad)
+			if err != nil {
+				return err
+			}
+			v.IKey = &k
+		case unix.IFLA_VTI_OKEY:
+			k, err := decodeBE32(ad)
+			if err != nil {
+				return err
+			}
+			v.OKey = &k
+		case unix.IFLA_VTI_LOCAL:
+			v.Local = ad.Bytes()
+		case unix.IFLA_VTI_REMOTE:
+			v.Remote = ad.Bytes()
+		case unix.IFLA_VTI_FWMARK:
+			m := ad.Uint32()
+			v.FwMark = &m
+		}
+	}
+	return ad.Err()
+}
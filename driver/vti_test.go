@@ -0,0 +1,190 @@
+package driver
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/mdlayher/netlink"
+	"github.com/mdlayher/netlink/nlenc"
+)
+
+func TestVtiRoundTrip(t *testing.T) {
+	var (
+		link   uint32 = 2
+		ikey   uint32 = 0x01020304
+		okey   uint32 = 0x05060708
+		fwmark uint32 = 0x10
+	)
+
+	tests := []struct {
+		name string
+		vti  rtnetlink.LinkDriver
+	}{
+		{
+			name: "vti empty",
+			vti:  &Vti{},
+		},
+		{
+			name: "vti full",
+			vti: &Vti{
+				Local:  net.IPv4(192, 0, 2, 1).To4(),
+				Remote: net.IPv4(192, 0, 2, 2).To4(),
+				Link:   &link,
+				IKey:   &ikey,
+				OKey:   &okey,
+				FwMark: &fwmark,
+			},
+		},
+		{
+			name: "vti6 empty",
+			vti:  &Vti6{},
+		},
+		{
+			name: "vti6 full",
+			vti: &Vti6{
+				Local:  net.ParseIP("2001:db8::1"),
+				Remote: net.ParseIP("2001:db8::2"),
+				Link:   &link,
+				IKey:   &ikey,
+				OKey:   &okey,
+				FwMark: &fwmark,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RoundTrip(tt.vti)
+			if err != nil {
+				t.Fatalf("failed to round trip vti: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.vti, got); diff != "" {
+				t.Fatalf("unexpected vti (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVtiEncode(t *testing.T) {
+	var (
+		key    uint32 = 0x01020304
+		fwmark uint32 = 0x10
+	)
+
+	tests := []struct {
+		name string
+		vti  rtnetlink.LinkDriver
+		b    []byte
+	}{
+		{
+			name: "keys",
+			vti: &Vti{
+				IKey: &key,
+				OKey: &key,
+			},
+			b: []byte{
+				0x08, 0x00, 0x02, 0x00, 0x01, 0x02, 0x03, 0x04,
+				0x08, 0x00, 0x03, 0x00, 0x01, 0x02, 0x03, 0x04,
+			},
+		},
+		{
+			name: "fwmark",
+			vti: &Vti{
+				FwMark: &fwmark,
+			},
+			b: append([]byte{0x08, 0x00, 0x06, 0x00}, nlenc.Uint32Bytes(fwmark)...),
+		},
+		{
+			name: "vti IPv4-mapped endpoint",
+			vti: &Vti{
+				Local: net.IPv4(192, 0, 2, 1),
+			},
+			b: []byte{
+				0x08, 0x00, 0x04, 0x00, 0xc0, 0x00, 0x02, 0x01,
+			},
+		},
+		{
+			name: "vti6 endpoint",
+			vti: &Vti6{
+				Remote: net.ParseIP("2001:db8::2"),
+			},
+			b: []byte{
+				0x14, 0x00, 0x05, 0x00,
+				0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ae := netlink.NewAttributeEncoder()
+			if err := tt.vti.Encode(ae); err != nil {
+				t.Fatalf("failed to encode vti: %v", err)
+			}
+			b, err := ae.Encode()
+			if err != nil {
+				t.Fatalf("failed to encode attributes: %v", err)
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("unexpected bytes:\n- want: [%# x]\n-  got: [%# x]", want, got)
+			}
+		})
+	}
+}
+
+func TestVtiVerify(t *testing.T) {
+	tests := []struct {
+		name string
+		vti  rtnetlink.LinkDriverVerifier
+		ok   bool
+	}{
+		{
+			name: "vti IPv4 endpoints",
+			vti: &Vti{
+				Local:  net.IPv4(192, 0, 2, 1),
+				Remote: net.IPv4(192, 0, 2, 2),
+			},
+			ok: true,
+		},
+		{
+			name: "vti IPv6 remote",
+			vti: &Vti{
+				Local:  net.IPv4(192, 0, 2, 1),
+				Remote: net.ParseIP("2001:db8::2"),
+			},
+		},
+		{
+			name: "vti6 IPv6 endpoints",
+			vti: &Vti6{
+				Local:  net.ParseIP("2001:db8::1"),
+				Remote: net.ParseIP("2001:db8::2"),
+			},
+			ok: true,
+		},
+		{
+			name: "vti6 IPv4 local",
+			vti: &Vti6{
+				Local:  net.IPv4(192, 0, 2, 1),
+				Remote: net.ParseIP("2001:db8::2"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.vti.Verify(&rtnetlink.LinkMessage{})
+			if tt.ok && err != nil {
+				t.Fatalf("failed to verify vti: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
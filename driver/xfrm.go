@@ -0,0 +1,56 @@
+package driver
+
+import (
+	"errors"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+// Xfrm implements LinkDriverVerifier for the xfrm driver
+type Xfrm struct {
+	Link *uint32 // Specifies the index of the underlying device
+	IfID *uint32 // Specifies the xfrm interface identifier matched by IPsec policies and states, required
+}
+
+var _ rtnetlink.LinkDriverVerifier = &Xfrm{}
+
+func (x *Xfrm) New() rtnetlink.LinkDriver {
+	return &Xfrm{}
+}
+
+func (x *Xfrm) Verify(msg *rtnetlink.LinkMessage) error {
+	if x.IfID == nil || *x.IfID == 0 {
+		return errors.New("xfrm interface requires a non-zero interface identifier")
+	}
+	return nil
+}
+
+func (x *Xfrm) Encode(ae *netlink.AttributeEncoder) error {
+	if x.Link != nil {
+		ae.Uint32(unix.IFLA_XFRM_LINK, *x.Link)
+	}
+	if x.IfID != nil {
+		ae.Uint32(unix.IFLA_XFRM_IF_ID, *x.IfID)
+	}
+	return nil
+}
+
+func (x *Xfrm) Decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_XFRM_LINK:
+			v := ad.Uint32()
+			x.Link = &v
+		case unix.IFLA_XFRM_IF_ID:
+			v := ad.Uint32()
+			x.IfID = &v
+		}
+	}
+	return nil
+}
+
+func (*Xfrm) Kind() string {
+	return "xfrm"
+}
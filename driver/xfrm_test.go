@@ -0,0 +1,65 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+)
+
+func TestXfrmRoundTrip(t *testing.T) {
+	var (
+		link uint32 = 2
+		ifID uint32 = 42
+	)
+
+	for _, xfrm := range []*Xfrm{{}, {IfID: &ifID}, {Link: &link, IfID: &ifID}} {
+		got, err := RoundTrip(xfrm)
+		if err != nil {
+			t.Fatalf("failed to round trip xfrm: %v", err)
+		}
+
+		if diff := cmp.Diff(rtnetlink.LinkDriver(xfrm), got); diff != "" {
+			t.Fatalf("unexpected xfrm (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestXfrmVerify(t *testing.T) {
+	var (
+		zero uint32
+		ifID uint32 = 42
+	)
+
+	tests := []struct {
+		name string
+		xfrm *Xfrm
+		ok   bool
+	}{
+		{
+			name: "missing interface identifier",
+			xfrm: &Xfrm{},
+		},
+		{
+			name: "zero interface identifier",
+			xfrm: &Xfrm{IfID: &zero},
+		},
+		{
+			name: "interface identifier",
+			xfrm: &Xfrm{IfID: &ifID},
+			ok:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.xfrm.Verify(&rtnetlink.LinkMessage{})
+			if tt.ok && err != nil {
+				t.Fatalf("failed to verify xfrm: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
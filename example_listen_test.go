@@ -0,0 +1,39 @@
+package rtnetlink_test
+
+import (
+	"log"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+)
+
+// Watch link and IPv4 address changes
+func Example_listen() {
+	// Dial a connection to the rtnetlink socket
+	conn, err := rtnetlink.Dial(nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	// Join the multicast groups of the notifications of interest
+	if err := conn.Listen(rtnetlink.GroupLink, rtnetlink.GroupIPv4Address); err != nil {
+		log.Fatal(err)
+	}
+
+	for {
+		// Receive decodes the notifications into typed messages
+		msgs, _, err := conn.Receive()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, m := range msgs {
+			switch m := m.(type) {
+			case *rtnetlink.LinkMessage:
+				log.Printf("link %d changed, flags: %#x", m.Index, m.Flags)
+			case *rtnetlink.AddressMessage:
+				log.Printf("address of link %d changed", m.Index)
+			}
+		}
+	}
+}
@@ -214,7 +214,232 @@ const (
 	CLONE_NEWNET                               = linux.CLONE_NEWNET
 	O_RDONLY                                   = linux.O_RDONLY
 	O_CLOEXEC                                  = linux.O_CLOEXEC
+	RTA_IIF                                    = linux.RTA_IIF
+	RTN_MULTICAST                              = linux.RTN_MULTICAST
+	IFNAMSIZ                                   = linux.IFNAMSIZ
+	RTA_FLOW                                   = linux.RTA_FLOW
+	RTPROT_UNSPEC                              = linux.RTPROT_UNSPEC
+	RTPROT_REDIRECT                            = linux.RTPROT_REDIRECT
+	RTPROT_KERNEL                              = linux.RTPROT_KERNEL
+	RTPROT_GATED                               = linux.RTPROT_GATED
+	RTPROT_RA                                  = linux.RTPROT_RA
+	RTPROT_MRT                                 = linux.RTPROT_MRT
+	RTPROT_ZEBRA                               = linux.RTPROT_ZEBRA
+	RTPROT_BIRD                                = linux.RTPROT_BIRD
+	RTPROT_DNROUTED                            = linux.RTPROT_DNROUTED
+	RTPROT_XORP                                = linux.RTPROT_XORP
+	RTPROT_NTK                                 = linux.RTPROT_NTK
+	RTPROT_DHCP                                = linux.RTPROT_DHCP
+	RTPROT_MROUTED                             = linux.RTPROT_MROUTED
+	RTPROT_KEEPALIVED                          = linux.RTPROT_KEEPALIVED
+	RTPROT_BABEL                               = linux.RTPROT_BABEL
+	RTPROT_OPENR                               = linux.RTPROT_OPENR
+	RTPROT_BGP                                 = linux.RTPROT_BGP
+	RTPROT_ISIS                                = linux.RTPROT_ISIS
+	RTPROT_OSPF                                = linux.RTPROT_OSPF
+	RTPROT_RIP                                 = linux.RTPROT_RIP
+	RTPROT_EIGRP                               = linux.RTPROT_EIGRP
+	AF_BRIDGE                                  = linux.AF_BRIDGE
+	NTF_SELF                                   = linux.NTF_SELF
+	NTF_MASTER                                 = linux.NTF_MASTER
+	NUD_PERMANENT                              = linux.NUD_PERMANENT
+	NUD_NOARP                                  = linux.NUD_NOARP
+	IFLA_AF_SPEC                               = linux.IFLA_AF_SPEC
+	IFLA_INET_CONF                             = linux.IFLA_INET_CONF
+	IFA_F_SECONDARY                            = linux.IFA_F_SECONDARY
+	RTM_F_NOTIFY                               = linux.RTM_F_NOTIFY
+	RTM_F_CLONED                               = linux.RTM_F_CLONED
+	RTM_F_EQUALIZE                             = linux.RTM_F_EQUALIZE
+	RTM_F_PREFIX                               = linux.RTM_F_PREFIX
+	RTM_F_LOOKUP_TABLE                         = linux.RTM_F_LOOKUP_TABLE
+	RTM_F_FIB_MATCH                            = linux.RTM_F_FIB_MATCH
+	RTM_F_OFFLOAD                              = linux.RTM_F_OFFLOAD
+	RTM_F_TRAP                                 = linux.RTM_F_TRAP
+	RTM_F_OFFLOAD_FAILED                       = linux.RTM_F_OFFLOAD_FAILED
+	FR_ACT_TO_TBL                              = linux.FR_ACT_TO_TBL
+	IFLA_GSO_MAX_SEGS                          = linux.IFLA_GSO_MAX_SEGS
+	IFLA_GSO_MAX_SIZE                          = linux.IFLA_GSO_MAX_SIZE
+	IFLA_GRO_MAX_SIZE                          = linux.IFLA_GRO_MAX_SIZE
+	IFLA_GSO_IPV4_MAX_SIZE                     = linux.IFLA_GSO_IPV4_MAX_SIZE
+	IFLA_GRO_IPV4_MAX_SIZE                     = linux.IFLA_GRO_IPV4_MAX_SIZE
+	IFLA_DPLL_PIN                              = linux.IFLA_DPLL_PIN
+	RTA_NH_ID                                  = 0x1e
+	IFLA_EXT_MASK                              = linux.IFLA_EXT_MASK
+	RTEXT_FILTER_SKIP_STATS                    = 0x8
+	IFLA_GENEVE_ID                             = linux.IFLA_GENEVE_ID
+	IFLA_GENEVE_REMOTE                         = linux.IFLA_GENEVE_REMOTE
+	IFLA_GENEVE_TTL                            = linux.IFLA_GENEVE_TTL
+	IFLA_GENEVE_TOS                            = linux.IFLA_GENEVE_TOS
+	IFLA_GENEVE_PORT                           = linux.IFLA_GENEVE_PORT
+	IFLA_GENEVE_COLLECT_METADATA               = linux.IFLA_GENEVE_COLLECT_METADATA
+	IFLA_GENEVE_REMOTE6                        = linux.IFLA_GENEVE_REMOTE6
+	IFLA_GENEVE_UDP_CSUM                       = linux.IFLA_GENEVE_UDP_CSUM
+	IFLA_GENEVE_DF                             = linux.IFLA_GENEVE_DF
+	IFLA_IPVLAN_MODE                           = linux.IFLA_IPVLAN_MODE
+	IFLA_IPVLAN_FLAGS                          = linux.IFLA_IPVLAN_FLAGS
+	IPVLAN_MODE_L2                             = 0x0
+	IPVLAN_MODE_L3                             = 0x1
+	IPVLAN_MODE_L3S                            = 0x2
+	IPVLAN_F_PRIVATE                           = 0x1
+	IPVLAN_F_VEPA                              = 0x2
+	IFLA_VRF_TABLE                             = linux.IFLA_VRF_TABLE
+	IFLA_GENEVE_TTL_INHERIT                    = linux.IFLA_GENEVE_TTL_INHERIT
+	IFLA_GENEVE_INNER_PROTO_INHERIT            = linux.IFLA_GENEVE_INNER_PROTO_INHERIT
+	IFLA_TUN_OWNER                             = linux.IFLA_TUN_OWNER
+	IFLA_TUN_GROUP                             = linux.IFLA_TUN_GROUP
+	IFLA_TUN_TYPE                              = linux.IFLA_TUN_TYPE
+	IFLA_TUN_PI                                = linux.IFLA_TUN_PI
+	IFLA_TUN_VNET_HDR                          = linux.IFLA_TUN_VNET_HDR
+	IFLA_TUN_PERSIST                           = linux.IFLA_TUN_PERSIST
+	IFLA_TUN_MULTI_QUEUE                       = linux.IFLA_TUN_MULTI_QUEUE
+	IFLA_TUN_NUM_QUEUES                        = linux.IFLA_TUN_NUM_QUEUES
+	IFLA_TUN_NUM_DISABLED_QUEUES               = linux.IFLA_TUN_NUM_DISABLED_QUEUES
+	IFF_TUN                                    = linux.IFF_TUN
+	IFF_TAP                                    = linux.IFF_TAP
+	IFLA_MACSEC_SCI                            = linux.IFLA_MACSEC_SCI
+	IFLA_MACSEC_PORT                           = linux.IFLA_MACSEC_PORT
+	IFLA_MACSEC_ICV_LEN                        = linux.IFLA_MACSEC_ICV_LEN
+	IFLA_MACSEC_CIPHER_SUITE                   = linux.IFLA_MACSEC_CIPHER_SUITE
+	IFLA_MACSEC_WINDOW                         = linux.IFLA_MACSEC_WINDOW
+	IFLA_MACSEC_ENCODING_SA                    = linux.IFLA_MACSEC_ENCODING_SA
+	IFLA_MACSEC_ENCRYPT                        = linux.IFLA_MACSEC_ENCRYPT
+	IFLA_MACSEC_PROTECT                        = linux.IFLA_MACSEC_PROTECT
+	IFLA_MACSEC_INC_SCI                        = linux.IFLA_MACSEC_INC_SCI
+	IFLA_MACSEC_ES                             = linux.IFLA_MACSEC_ES
+	IFLA_MACSEC_SCB                            = linux.IFLA_MACSEC_SCB
+	IFLA_MACSEC_REPLAY_PROTECT                 = linux.IFLA_MACSEC_REPLAY_PROTECT
+	IFLA_MACSEC_VALIDATION                     = linux.IFLA_MACSEC_VALIDATION
+	MACSEC_VALIDATE_DISABLED                   = 0x0
+	MACSEC_VALIDATE_CHECK                      = 0x1
+	MACSEC_VALIDATE_STRICT                     = 0x2
+	MACSEC_CIPHER_ID_GCM_AES_128               = 0x0080c20001000001
+	MACSEC_CIPHER_ID_GCM_AES_256               = 0x0080c20001000002
+	MACSEC_CIPHER_ID_GCM_AES_XPN_128           = 0x0080c20001000003
+	MACSEC_CIPHER_ID_GCM_AES_XPN_256           = 0x0080c20001000004
+	RTM_NEWNSID                                = linux.RTM_NEWNSID
+	RTM_GETNSID                                = linux.RTM_GETNSID
+	RTM_DELNSID                                = linux.RTM_DELNSID
+	NETNSA_NSID                                = linux.NETNSA_NSID
+	NETNSA_PID                                 = linux.NETNSA_PID
+	NETNSA_FD                                  = linux.NETNSA_FD
+	NETNSA_NSID_NOT_ASSIGNED                   = linux.NETNSA_NSID_NOT_ASSIGNED
+	IFLA_LINK_NETNSID                          = linux.IFLA_LINK_NETNSID
+	IFLA_BAREUDP_PORT                          = linux.IFLA_BAREUDP_PORT
+	IFLA_BAREUDP_ETHERTYPE                     = linux.IFLA_BAREUDP_ETHERTYPE
+	IFLA_BAREUDP_SRCPORT_MIN                   = linux.IFLA_BAREUDP_SRCPORT_MIN
+	IFLA_BAREUDP_MULTIPROTO_MODE               = linux.IFLA_BAREUDP_MULTIPROTO_MODE
+	RTA_SPORT                                  = linux.RTA_SPORT
+	RTA_DPORT                                  = linux.RTA_DPORT
+	IFLA_GTP_FD0                               = linux.IFLA_GTP_FD0
+	IFLA_GTP_FD1                               = linux.IFLA_GTP_FD1
+	IFLA_GTP_PDP_HASHSIZE                      = linux.IFLA_GTP_PDP_HASHSIZE
+	IFLA_GTP_ROLE                              = linux.IFLA_GTP_ROLE
+	GTP_ROLE_GGSN                              = 0x0
+	GTP_ROLE_SGSN                              = 0x1
+	IFLA_XFRM_LINK                             = linux.IFLA_XFRM_LINK
+	IFLA_XFRM_IF_ID                            = linux.IFLA_XFRM_IF_ID
+	IFLA_NETKIT_SCRUB                          = 0x6
+	IFLA_NETKIT_PEER_SCRUB                     = 0x7
+	IFLA_NETKIT_HEADROOM                       = 0x8
+	IFLA_NETKIT_TAILROOM                       = 0x9
+	NETKIT_SCRUB_NONE                          = 0x0
+	NETKIT_SCRUB_DEFAULT                       = 0x1
+	RTEXT_FILTER_BRVLAN                        = 0x2
+	IFLA_BRIDGE_FLAGS                          = 0x0
+	IFLA_BRIDGE_MODE                           = 0x1
+	IFLA_BRIDGE_VLAN_INFO                      = 0x2
+	IFLA_BRIDGE_VLAN_TUNNEL_INFO               = 0x3
+	IFLA_BRIDGE_VLAN_TUNNEL_ID                 = 0x1
+	IFLA_BRIDGE_VLAN_TUNNEL_VID                = 0x2
+	IFLA_BRIDGE_VLAN_TUNNEL_FLAGS              = 0x3
+	BRIDGE_FLAGS_MASTER                        = 0x1
+	BRIDGE_FLAGS_SELF                          = 0x2
+	BRIDGE_VLAN_INFO_MASTER                    = 0x1
+	BRIDGE_VLAN_INFO_PVID                      = 0x2
+	BRIDGE_VLAN_INFO_UNTAGGED                  = 0x4
+	BRIDGE_VLAN_INFO_RANGE_BEGIN               = 0x8
+	BRIDGE_VLAN_INFO_RANGE_END                 = 0x10
+	BRIDGE_VLAN_INFO_BRENTRY                   = 0x20
+	NDA_VLAN                                   = linux.NDA_VLAN
+	NDA_PORT                                   = linux.NDA_PORT
+	NDA_VNI                                    = linux.NDA_VNI
+	NDA_MASTER                                 = linux.NDA_MASTER
+	NDA_SRC_VNI                                = linux.NDA_SRC_VNI
+	RTNLGRP_LINK                               = linux.RTNLGRP_LINK
+	RTNLGRP_NEIGH                              = linux.RTNLGRP_NEIGH
+	RTNLGRP_IPV4_IFADDR                        = linux.RTNLGRP_IPV4_IFADDR
+	RTNLGRP_IPV6_IFADDR                        = linux.RTNLGRP_IPV6_IFADDR
+	RTNLGRP_IPV4_ROUTE                         = linux.RTNLGRP_IPV4_ROUTE
+	RTNLGRP_IPV6_ROUTE                         = linux.RTNLGRP_IPV6_ROUTE
+	RTA_VIA                                    = linux.RTA_VIA
+	LWTUNNEL_ENCAP_IP                          = linux.LWTUNNEL_ENCAP_IP
+	RTAX_HOPLIMIT                              = linux.RTAX_HOPLIMIT
+	RTAX_RTT                                   = linux.RTAX_RTT
+	RTAX_RTTVAR                                = linux.RTAX_RTTVAR
+	RTAX_SSTHRESH                              = linux.RTAX_SSTHRESH
+	RTAX_CWND                                  = linux.RTAX_CWND
+	RTAX_REORDERING                            = linux.RTAX_REORDERING
+	RTAX_WINDOW                                = linux.RTAX_WINDOW
+	RTAX_QUICKACK                              = linux.RTAX_QUICKACK
+	RTAX_CC_ALGO                               = linux.RTAX_CC_ALGO
+	RTA_CACHEINFO                              = linux.RTA_CACHEINFO
+	IFLA_INET6_FLAGS                           = linux.IFLA_INET6_FLAGS
+	IFLA_INET6_CONF                            = linux.IFLA_INET6_CONF
+	IFLA_INET6_TOKEN                           = linux.IFLA_INET6_TOKEN
+	IFLA_INET6_ADDR_GEN_MODE                   = linux.IFLA_INET6_ADDR_GEN_MODE
+	IN6_ADDR_GEN_MODE_EUI64                    = 0x0
+	IN6_ADDR_GEN_MODE_NONE                     = 0x1
+	IN6_ADDR_GEN_MODE_STABLE_PRIVACY           = 0x2
+	IN6_ADDR_GEN_MODE_RANDOM                   = 0x3
+	RTM_NEWLINKPROP                            = linux.RTM_NEWLINKPROP
+	RTM_DELLINKPROP                            = linux.RTM_DELLINKPROP
+	ALTIFNAMSIZ                                = 0x80
+	IFA_F_NODAD                                = linux.IFA_F_NODAD
+	IFA_F_MANAGETEMPADDR                       = linux.IFA_F_MANAGETEMPADDR
+	IFLA_GRE_LINK                              = 0x1
+	IFLA_GRE_IFLAGS                            = 0x2
+	IFLA_GRE_OFLAGS                            = 0x3
+	IFLA_GRE_IKEY                              = 0x4
+	IFLA_GRE_OKEY                              = 0x5
+	IFLA_GRE_LOCAL                             = 0x6
+	IFLA_GRE_REMOTE                            = 0x7
+	IFLA_GRE_TTL                               = 0x8
+	IFLA_GRE_TOS                               = 0x9
+	IFLA_GRE_PMTUDISC                          = 0xa
+	IFLA_GRE_ENCAP_TYPE                        = 0xe
+	IFLA_GRE_ENCAP_FLAGS                       = 0xf
+	IFLA_GRE_ENCAP_SPORT                       = 0x10
+	IFLA_GRE_ENCAP_DPORT                       = 0x11
+	IFLA_GRE_COLLECT_METADATA                  = 0x12
+	IFLA_GRE_IGNORE_DF                         = 0x13
+	IFLA_GRE_FWMARK                            = 0x14
+	IFLA_IPTUN_LINK                            = 0x1
+	IFLA_IPTUN_LOCAL                           = 0x2
+	IFLA_IPTUN_REMOTE                          = 0x3
+	IFLA_IPTUN_TTL                             = 0x4
+	IFLA_IPTUN_TOS                             = 0x5
+	IFLA_IPTUN_ENCAP_LIMIT                     = 0x6
+	IFLA_IPTUN_FLOWINFO                        = 0x7
+	IFLA_IPTUN_FLAGS                           = 0x8
+	IFLA_IPTUN_PROTO                           = 0x9
+	IFLA_IPTUN_PMTUDISC                        = 0xa
+	IFLA_IPTUN_6RD_PREFIX                      = 0xb
+	IFLA_IPTUN_6RD_RELAY_PREFIX                = 0xc
+	IFLA_IPTUN_6RD_PREFIXLEN                   = 0xd
+	IFLA_IPTUN_6RD_RELAY_PREFIXLEN             = 0xe
+	IFLA_VTI_LINK                              = 0x1
+	IFLA_VTI_IKEY                              = 0x2
+	IFLA_VTI_OKEY                              = 0x3
+	IFLA_VTI_LOCAL                             = 0x4
+	IFLA_VTI_REMOTE                            = 0x5
+	IFLA_VTI_FWMARK                            = 0x6
 )
 
+var ENODEV = linux.ENODEV
+
 var Gettid = linux.Gettid
 var Unshare = linux.Unshare
+
+type Stat_t = linux.Stat_t
+
+var Fstat = linux.Fstat
@@ -3,6 +3,8 @@
 
 package unix
 
+import "errors"
+
 const (
 	AF_INET                                    = 0x2
 	AF_INET6                                   = 0xa
@@ -210,8 +212,229 @@ const (
 	CLONE_NEWNET                               = 0x40000000
 	O_RDONLY                                   = 0x0
 	O_CLOEXEC                                  = 0x80000
+	RTA_IIF                                    = 0x3
+	RTN_MULTICAST                              = 0x5
+	IFNAMSIZ                                   = 0x10
+	RTA_FLOW                                   = 0xb
+	RTPROT_UNSPEC                              = 0x0
+	RTPROT_REDIRECT                            = 0x1
+	RTPROT_KERNEL                              = 0x2
+	RTPROT_GATED                               = 0x8
+	RTPROT_RA                                  = 0x9
+	RTPROT_MRT                                 = 0xa
+	RTPROT_ZEBRA                               = 0xb
+	RTPROT_BIRD                                = 0xc
+	RTPROT_DNROUTED                            = 0xd
+	RTPROT_XORP                                = 0xe
+	RTPROT_NTK                                 = 0xf
+	RTPROT_DHCP                                = 0x10
+	RTPROT_MROUTED                             = 0x11
+	RTPROT_KEEPALIVED                          = 0x12
+	RTPROT_BABEL                               = 0x2a
+	RTPROT_OPENR                               = 0x63
+	RTPROT_BGP                                 = 0xba
+	RTPROT_ISIS                                = 0xbb
+	RTPROT_OSPF                                = 0xbc
+	RTPROT_RIP                                 = 0xbd
+	RTPROT_EIGRP                               = 0xc0
+	AF_BRIDGE                                  = 0x7
+	NTF_SELF                                   = 0x2
+	NTF_MASTER                                 = 0x4
+	NUD_PERMANENT                              = 0x80
+	NUD_NOARP                                  = 0x40
+	IFLA_AF_SPEC                               = 0x1a
+	IFLA_INET_CONF                             = 0x1
+	IFA_F_SECONDARY                            = 0x1
+	RTM_F_NOTIFY                               = 0x100
+	RTM_F_CLONED                               = 0x200
+	RTM_F_EQUALIZE                             = 0x400
+	RTM_F_PREFIX                               = 0x800
+	RTM_F_LOOKUP_TABLE                         = 0x1000
+	RTM_F_FIB_MATCH                            = 0x2000
+	RTM_F_OFFLOAD                              = 0x4000
+	RTM_F_TRAP                                 = 0x8000
+	RTM_F_OFFLOAD_FAILED                       = 0x20000000
+	FR_ACT_TO_TBL                              = 0x1
+	IFLA_GSO_MAX_SEGS                          = 0x28
+	IFLA_GSO_MAX_SIZE                          = 0x29
+	IFLA_GRO_MAX_SIZE                          = 0x3a
+	IFLA_GSO_IPV4_MAX_SIZE                     = 0x3f
+	IFLA_GRO_IPV4_MAX_SIZE                     = 0x40
+	IFLA_DPLL_PIN                              = 0x41
+	RTA_NH_ID                                  = 0x1e
+	IFLA_EXT_MASK                              = 0x1d
+	RTEXT_FILTER_SKIP_STATS                    = 0x8
+	IFLA_GENEVE_ID                             = 0x1
+	IFLA_GENEVE_REMOTE                         = 0x2
+	IFLA_GENEVE_TTL                            = 0x3
+	IFLA_GENEVE_TOS                            = 0x4
+	IFLA_GENEVE_PORT                           = 0x5
+	IFLA_GENEVE_COLLECT_METADATA               = 0x6
+	IFLA_GENEVE_REMOTE6                        = 0x7
+	IFLA_GENEVE_UDP_CSUM                       = 0x8
+	IFLA_GENEVE_DF                             = 0xd
+	IFLA_IPVLAN_MODE                           = 0x1
+	IFLA_IPVLAN_FLAGS                          = 0x2
+	IPVLAN_MODE_L2                             = 0x0
+	IPVLAN_MODE_L3                             = 0x1
+	IPVLAN_MODE_L3S                            = 0x2
+	IPVLAN_F_PRIVATE                           = 0x1
+	IPVLAN_F_VEPA                              = 0x2
+	IFLA_VRF_TABLE                             = 0x1
+	IFLA_GENEVE_TTL_INHERIT                    = 0xc
+	IFLA_GENEVE_INNER_PROTO_INHERIT            = 0xe
+	IFLA_TUN_OWNER                             = 0x1
+	IFLA_TUN_GROUP                             = 0x2
+	IFLA_TUN_TYPE                              = 0x3
+	IFLA_TUN_PI                                = 0x4
+	IFLA_TUN_VNET_HDR                          = 0x5
+	IFLA_TUN_PERSIST                           = 0x6
+	IFLA_TUN_MULTI_QUEUE                       = 0x7
+	IFLA_TUN_NUM_QUEUES                        = 0x8
+	IFLA_TUN_NUM_DISABLED_QUEUES               = 0x9
+	IFF_TUN                                    = 0x1
+	IFF_TAP                                    = 0x2
+	IFLA_MACSEC_SCI                            = 0x1
+	IFLA_MACSEC_PORT                           = 0x2
+	IFLA_MACSEC_ICV_LEN                        = 0x3
+	IFLA_MACSEC_CIPHER_SUITE                   = 0x4
+	IFLA_MACSEC_WINDOW                         = 0x5
+	IFLA_MACSEC_ENCODING_SA                    = 0x6
+	IFLA_MACSEC_ENCRYPT                        = 0x7
+	IFLA_MACSEC_PROTECT                        = 0x8
+	IFLA_MACSEC_INC_SCI                        = 0x9
+	IFLA_MACSEC_ES                             = 0xa
+	IFLA_MACSEC_SCB                            = 0xb
+	IFLA_MACSEC_REPLAY_PROTECT                 = 0xc
+	IFLA_MACSEC_VALIDATION                     = 0xd
+	MACSEC_VALIDATE_DISABLED                   = 0x0
+	MACSEC_VALIDATE_CHECK                      = 0x1
+	MACSEC_VALIDATE_STRICT                     = 0x2
+	MACSEC_CIPHER_ID_GCM_AES_128               = 0x0080c20001000001
+	MACSEC_CIPHER_ID_GCM_AES_256               = 0x0080c20001000002
+	MACSEC_CIPHER_ID_GCM_AES_XPN_128           = 0x0080c20001000003
+	MACSEC_CIPHER_ID_GCM_AES_XPN_256           = 0x0080c20001000004
+	RTM_NEWNSID                                = 0x58
+	RTM_GETNSID                                = 0x5a
+	RTM_DELNSID                                = 0x59
+	NETNSA_NSID                                = 0x1
+	NETNSA_PID                                 = 0x2
+	NETNSA_FD                                  = 0x3
+	NETNSA_NSID_NOT_ASSIGNED                   = -0x1
+	IFLA_LINK_NETNSID                          = 0x25
+	IFLA_BAREUDP_PORT                          = 0x1
+	IFLA_BAREUDP_ETHERTYPE                     = 0x2
+	IFLA_BAREUDP_SRCPORT_MIN                   = 0x3
+	IFLA_BAREUDP_MULTIPROTO_MODE               = 0x4
+	RTA_SPORT                                  = 0x1c
+	RTA_DPORT                                  = 0x1d
+	IFLA_GTP_FD0                               = 0x1
+	IFLA_GTP_FD1                               = 0x2
+	IFLA_GTP_PDP_HASHSIZE                      = 0x3
+	IFLA_GTP_ROLE                              = 0x4
+	GTP_ROLE_GGSN                              = 0x0
+	GTP_ROLE_SGSN                              = 0x1
+	IFLA_XFRM_LINK                             = 0x1
+	IFLA_XFRM_IF_ID                            = 0x2
+	IFLA_NETKIT_SCRUB                          = 0x6
+	IFLA_NETKIT_PEER_SCRUB                     = 0x7
+	IFLA_NETKIT_HEADROOM                       = 0x8
+	IFLA_NETKIT_TAILROOM                       = 0x9
+	NETKIT_SCRUB_NONE                          = 0x0
+	NETKIT_SCRUB_DEFAULT                       = 0x1
+	RTEXT_FILTER_BRVLAN                        = 0x2
+	IFLA_BRIDGE_FLAGS                          = 0x0
+	IFLA_BRIDGE_MODE                           = 0x1
+	IFLA_BRIDGE_VLAN_INFO                      = 0x2
+	IFLA_BRIDGE_VLAN_TUNNEL_INFO               = 0x3
+	IFLA_BRIDGE_VLAN_TUNNEL_ID                 = 0x1
+	IFLA_BRIDGE_VLAN_TUNNEL_VID                = 0x2
+	IFLA_BRIDGE_VLAN_TUNNEL_FLAGS              = 0x3
+	BRIDGE_FLAGS_MASTER                        = 0x1
+	BRIDGE_FLAGS_SELF                          = 0x2
+	BRIDGE_VLAN_INFO_MASTER                    = 0x1
+	BRIDGE_VLAN_INFO_PVID                      = 0x2
+	BRIDGE_VLAN_INFO_UNTAGGED                  = 0x4
+	BRIDGE_VLAN_INFO_RANGE_BEGIN               = 0x8
+	BRIDGE_VLAN_INFO_RANGE_END                 = 0x10
+	BRIDGE_VLAN_INFO_BRENTRY                   = 0x20
+	NDA_VLAN                                   = 0x5
+	NDA_PORT                                   = 0x6
+	NDA_VNI                                    = 0x7
+	NDA_MASTER                                 = 0x9
+	NDA_SRC_VNI                                = 0xb
+	RTNLGRP_LINK                               = 0x1
+	RTNLGRP_NEIGH                              = 0x3
+	RTNLGRP_IPV4_IFADDR                        = 0x5
+	RTNLGRP_IPV6_IFADDR                        = 0x9
+	RTNLGRP_IPV4_ROUTE                         = 0x7
+	RTNLGRP_IPV6_ROUTE                         = 0xb
+	RTA_VIA                                    = 0x12
+	LWTUNNEL_ENCAP_IP                          = 0x2
+	RTAX_HOPLIMIT                              = 0xa
+	RTAX_RTT                                   = 0x4
+	RTAX_RTTVAR                                = 0x5
+	RTAX_SSTHRESH                              = 0x6
+	RTAX_CWND                                  = 0x7
+	RTAX_REORDERING                            = 0x9
+	RTAX_WINDOW                                = 0x3
+	RTAX_QUICKACK                              = 0xf
+	RTAX_CC_ALGO                               = 0x10
+	RTA_CACHEINFO                              = 0xc
+	IFLA_INET6_FLAGS                           = 0x1
+	IFLA_INET6_CONF                            = 0x2
+	IFLA_INET6_TOKEN                           = 0x7
+	IFLA_INET6_ADDR_GEN_MODE                   = 0x8
+	IN6_ADDR_GEN_MODE_EUI64                    = 0x0
+	IN6_ADDR_GEN_MODE_NONE                     = 0x1
+	IN6_ADDR_GEN_MODE_STABLE_PRIVACY           = 0x2
+	IN6_ADDR_GEN_MODE_RANDOM                   = 0x3
+	RTM_NEWLINKPROP                            = 0x6c
+	RTM_DELLINKPROP                            = 0x6d
+	ALTIFNAMSIZ                                = 0x80
+	IFA_F_NODAD                                = 0x2
+	IFA_F_MANAGETEMPADDR                       = 0x100
+	IFLA_GRE_LINK                              = 0x1
+	IFLA_GRE_IFLAGS                            = 0x2
+	IFLA_GRE_OFLAGS                            = 0x3
+	IFLA_GRE_IKEY                              = 0x4
+	IFLA_GRE_OKEY                              = 0x5
+	IFLA_GRE_LOCAL                             = 0x6
+	IFLA_GRE_REMOTE                            = 0x7
+	IFLA_GRE_TTL                               = 0x8
+	IFLA_GRE_TOS                               = 0x9
+	IFLA_GRE_PMTUDISC                          = 0xa
+	IFLA_GRE_ENCAP_TYPE                        = 0xe
+	IFLA_GRE_ENCAP_FLAGS                       = 0xf
+	IFLA_GRE_ENCAP_SPORT                       = 0x10
+	IFLA_GRE_ENCAP_DPORT                       = 0x11
+	IFLA_GRE_COLLECT_METADATA                  = 0x12
+	IFLA_GRE_IGNORE_DF                         = 0x13
+	IFLA_GRE_FWMARK                            = 0x14
+	IFLA_IPTUN_LINK                            = 0x1
+	IFLA_IPTUN_LOCAL                           = 0x2
+	IFLA_IPTUN_REMOTE                          = 0x3
+	IFLA_IPTUN_TTL                             = 0x4
+	IFLA_IPTUN_TOS                             = 0x5
+	IFLA_IPTUN_ENCAP_LIMIT                     = 0x6
+	IFLA_IPTUN_FLOWINFO                        = 0x7
+	IFLA_IPTUN_FLAGS                           = 0x8
+	IFLA_IPTUN_PROTO                           = 0x9
+	IFLA_IPTUN_PMTUDISC                        = 0xa
+	IFLA_IPTUN_6RD_PREFIX                      = 0xb
+	IFLA_IPTUN_6RD_RELAY_PREFIX                = 0xc
+	IFLA_IPTUN_6RD_PREFIXLEN                   = 0xd
+	IFLA_IPTUN_6RD_RELAY_PREFIXLEN             = 0xe
+	IFLA_VTI_LINK                              = 0x1
+	IFLA_VTI_IKEY                              = 0x2
+	IFLA_VTI_OKEY                              = 0x3
+	IFLA_VTI_LOCAL                             = 0x4
+	IFLA_VTI_REMOTE                            = 0x5
+	IFLA_VTI_FWMARK                            = 0x6
 )
 
+var ENODEV = errors.New("no such device")
+
 func Unshare(_ int) error {
 	return nil
 }
@@ -219,3 +442,12 @@ func Unshare(_ int) error {
 func Gettid() int {
 	return 0
 }
+
+type Stat_t struct {
+	Dev uint64
+	Ino uint64
+}
+
+func Fstat(_ int, _ *Stat_t) error {
+	return errors.New("not implemented")
+}
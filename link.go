@@ -104,7 +104,7 @@ func (m *LinkMessage) UnmarshalBinary(b []byte) error {
 			return err
 		}
 		ad.ByteOrder = nativeEndian
-		err = m.Attributes.decode(ad)
+		err = m.Attributes.decode(ad, m.Family)
 		if err != nil {
 			return err
 		}
@@ -116,6 +116,44 @@ func (m *LinkMessage) UnmarshalBinary(b []byte) error {
 // rtMessage is an empty method to sattisfy the Message interface.
 func (*LinkMessage) rtMessage() {}
 
+// Validate verifies that the interface name of the LinkMessage fits within
+// IFNAMSIZ.
+func (m *LinkMessage) Validate() error {
+	if m.Attributes == nil {
+		return nil
+	}
+
+	if len(m.Attributes.Name) >= unix.IFNAMSIZ {
+		return fmt.Errorf("rtnetlink: interface name %q exceeds %d bytes", m.Attributes.Name, unix.IFNAMSIZ-1)
+	}
+
+	return nil
+}
+
+// IsOperational reports whether the interface is administratively up, has a
+// carrier when one is reported, and an operational state of up or unknown.
+// Virtual interfaces that do not implement operational state report unknown.
+func (m *LinkMessage) IsOperational() bool {
+	if m.Flags&unix.IFF_UP == 0 {
+		return false
+	}
+
+	if m.Attributes == nil {
+		return true
+	}
+
+	if m.Attributes.Carrier != nil && *m.Attributes.Carrier != 1 {
+		return false
+	}
+
+	switch m.Attributes.OperationalState {
+	case OperStateUp, OperStateUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
 // LinkService is used to retrieve rtnetlink family information.
 type LinkService struct {
 	c *Conn
@@ -135,6 +173,10 @@ func (l *LinkService) execute(m Message, family uint16, flags netlink.HeaderFlag
 
 // New creates a new interface using the LinkMessage information.
 func (l *LinkService) New(req *LinkMessage) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
 	flags := netlink.Request | netlink.Create | netlink.Acknowledge | netlink.Excl
 	_, err := l.execute(req, unix.RTM_NEWLINK, flags)
 
@@ -169,6 +211,173 @@ func (l *LinkService) Get(index uint32) (LinkMessage, error) {
 	return links[0], err
 }
 
+// GetByName retrieves interface information by interface name. If no
+// interface has the given name, the lookup is retried as an alternative
+// name. Names that do not fit within IFNAMSIZ can only be alternative names
+// and are looked up as such right away.
+func (l *LinkService) GetByName(name string) (*LinkMessage, error) {
+	if name == "" {
+		return nil, errors.New("rtnetlink: interface name must not be empty")
+	}
+
+	if len(name) < unix.IFNAMSIZ {
+		m, err := l.getByName(unix.IFLA_IFNAME, name)
+		if !errors.Is(err, unix.ENODEV) {
+			return m, err
+		}
+	}
+
+	return l.getByName(unix.IFLA_ALT_IFNAME, name)
+}
+
+// getByName looks up an interface by the name attribute typ.
+func (l *LinkService) getByName(typ uint16, name string) (*LinkMessage, error) {
+	hdr, err := (&LinkMessage{}).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	ae := netlink.NewAttributeEncoder()
+	ae.ByteOrder = nativeEndian
+	ae.String(typ, name)
+	attrs, err := ae.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := l.c.execute(netlink.Message{
+		Header: netlink.Header{
+			Type:  unix.RTM_GETLINK,
+			Flags: netlink.Request,
+		},
+		Data: append(hdr, attrs...),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rtmsgs, err := unpackMessages(msgs)
+	if err != nil {
+		return nil, err
+	}
+	if len(rtmsgs) != 1 {
+		return nil, fmt.Errorf("too many/little matches, expected 1, actual %d", len(rtmsgs))
+	}
+
+	m, ok := rtmsgs[0].(*LinkMessage)
+	if !ok {
+		return nil, fmt.Errorf("rtnetlink: unexpected reply %T to link lookup", rtmsgs[0])
+	}
+
+	return m, nil
+}
+
+// AddAltName adds the alternative name to the interface with the given
+// index. Alternative names may be longer than regular interface names and
+// can be used to look up an interface with GetByName.
+func (l *LinkService) AddAltName(index uint32, name string) error {
+	return l.altName(unix.RTM_NEWLINKPROP, index, name)
+}
+
+// DelAltName removes the alternative name from the interface with the given
+// index.
+func (l *LinkService) DelAltName(index uint32, name string) error {
+	return l.altName(unix.RTM_DELLINKPROP, index, name)
+}
+
+// altName sends a link property request of type typ for an alternative name.
+func (l *LinkService) altName(typ netlink.HeaderType, index uint32, name string) error {
+	if name == "" {
+		return errors.New("rtnetlink: alternative name must not be empty")
+	}
+	if len(name) >= unix.ALTIFNAMSIZ {
+		return fmt.Errorf("rtnetlink: alternative name %q exceeds %d bytes", name, unix.ALTIFNAMSIZ-1)
+	}
+
+	hdr, err := (&LinkMessage{Index: index}).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	ae := netlink.NewAttributeEncoder()
+	ae.ByteOrder = nativeEndian
+	ae.Nested(unix.IFLA_PROP_LIST, func(nae *netlink.AttributeEncoder) error {
+		nae.String(unix.IFLA_ALT_IFNAME, name)
+		return nil
+	})
+	attrs, err := ae.Encode()
+	if err != nil {
+		return err
+	}
+
+	_, err = l.c.execute(netlink.Message{
+		Header: netlink.Header{
+			Type:  typ,
+			Flags: netlink.Request | netlink.Acknowledge,
+		},
+		Data: append(hdr, attrs...),
+	})
+
+	return err
+}
+
+// LinkGSO holds the GSO and GRO limits to configure with LinkService.SetGSO.
+// Only the non-nil fields are sent to the kernel.
+type LinkGSO struct {
+	GSOMaxSegs     *uint32 // Maximum number of segments of a GSO packet
+	GSOMaxSize     *uint32 // Maximum size of a GSO packet
+	GROMaxSize     *uint32 // Maximum size of a GRO packet
+	GSOIPv4MaxSize *uint32 // Maximum size of an IPv4 GSO packet
+	GROIPv4MaxSize *uint32 // Maximum size of an IPv4 GRO packet
+}
+
+// SetGSO changes the GSO and GRO limits of the interface with the given
+// index. The limits are reported in LinkAttributes but are not part of a
+// Set request, so a LinkMessage returned by Get can be sent back without
+// changing them.
+func (l *LinkService) SetGSO(index uint32, gso *LinkGSO) error {
+	if gso == nil {
+		return errors.New("rtnetlink: GSO limits must not be nil")
+	}
+
+	hdr, err := (&LinkMessage{Index: index}).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	ae := netlink.NewAttributeEncoder()
+	ae.ByteOrder = nativeEndian
+	if gso.GSOMaxSegs != nil {
+		ae.Uint32(unix.IFLA_GSO_MAX_SEGS, *gso.GSOMaxSegs)
+	}
+	if gso.GSOMaxSize != nil {
+		ae.Uint32(unix.IFLA_GSO_MAX_SIZE, *gso.GSOMaxSize)
+	}
+	if gso.GROMaxSize != nil {
+		ae.Uint32(unix.IFLA_GRO_MAX_SIZE, *gso.GROMaxSize)
+	}
+	if gso.GSOIPv4MaxSize != nil {
+		ae.Uint32(unix.IFLA_GSO_IPV4_MAX_SIZE, *gso.GSOIPv4MaxSize)
+	}
+	if gso.GROIPv4MaxSize != nil {
+		ae.Uint32(unix.IFLA_GRO_IPV4_MAX_SIZE, *gso.GROIPv4MaxSize)
+	}
+	attrs, err := ae.Encode()
+	if err != nil {
+		return err
+	}
+
+	_, err = l.c.execute(netlink.Message{
+		Header: netlink.Header{
+			Type:  unix.RTM_NEWLINK,
+			Flags: netlink.Request | netlink.Acknowledge,
+		},
+		Data: append(hdr, attrs...),
+	})
+
+	return err
+}
+
 // Set sets interface attributes according to the LinkMessage information.
 //
 // ref: https://lwn.net/Articles/236919/
@@ -178,6 +387,11 @@ func (l *LinkService) Get(index uint32) (LinkMessage, error) {
 //     attributes common today
 //   - using RTM_NEWLINK is the preferred way to create AND update links
 //   - RTM_NEWLINK is backward compatible to RTM_SETLINK
+//
+// All attributes of the LinkMessage are sent in a single request, so several
+// of them can be changed at once. Only the flags selected by the Change mask
+// are modified. Setting attributes to their current values is a no-op. The
+// read only fields of LinkAttributes are ignored, see LinkAttributes.
 func (l *LinkService) Set(req *LinkMessage) error {
 	flags := netlink.Request | netlink.Acknowledge
 	_, err := l.c.Execute(req, unix.RTM_NEWLINK, flags)
@@ -185,6 +399,102 @@ func (l *LinkService) Set(req *LinkMessage) error {
 	return err
 }
 
+// Rename changes the name of the interface with the given index. Most
+// drivers only allow an interface to be renamed while it is down.
+func (l *LinkService) Rename(index uint32, name string) error {
+	if name == "" {
+		return errors.New("rtnetlink: interface name must not be empty")
+	}
+
+	req := &LinkMessage{
+		Index: index,
+		Attributes: &LinkAttributes{
+			Name: name,
+		},
+	}
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	return l.Set(req)
+}
+
+// Enslave attaches the interface with the given index to the master
+// interface and applies the optional slave specific configuration. A master
+// of 0 releases the interface from its current master, in which case
+// slaveData must be nil.
+//
+// Before sending any request, the registered drivers of both interfaces are
+// consulted through LinkDriverCapabilities to reject combinations the kernel
+// does not support. Both interfaces are only looked up for this when a
+// registered driver implements LinkDriverCapabilities.
+//
+// Enslave is not atomic when slaveData is set. The kernel resolves
+// IFLA_INFO_SLAVE_DATA against the master a link is already attached to and
+// rejects it with EOPNOTSUPP otherwise, so when the link is not yet a slave of
+// master, IFLA_MASTER is sent first and the slave data is applied in a second
+// request carrying both IFLA_MASTER and the slave info. In between, the link
+// is a slave of master with the default slave configuration. Otherwise a
+// single request is used.
+func (l *LinkService) Enslave(index, master uint32, slaveData LinkSlaveDriver) error {
+	req := &LinkMessage{
+		Index: index,
+		Attributes: &LinkAttributes{
+			Master: &master,
+		},
+	}
+
+	if master == 0 {
+		if slaveData != nil {
+			return errors.New("rtnetlink: slave data requires a master")
+		}
+		return l.Set(req)
+	}
+
+	var link *LinkMessage
+	if hasLinkCapabilities() {
+		m, err := l.Get(index)
+		if err != nil {
+			return err
+		}
+
+		masterLink, err := l.Get(master)
+		if err != nil {
+			return err
+		}
+
+		if err := checkEnslave(&m, &masterLink); err != nil {
+			return err
+		}
+		link = &m
+	}
+
+	if slaveData == nil {
+		return l.Set(req)
+	}
+
+	if link == nil {
+		m, err := l.Get(index)
+		if err != nil {
+			return err
+		}
+		link = &m
+	}
+
+	if link.Attributes == nil || link.Attributes.Master == nil || *link.Attributes.Master != master {
+		if err := l.Set(req); err != nil {
+			return err
+		}
+	}
+
+	req.Attributes.Info = &LinkInfo{
+		SlaveKind: slaveData.Kind(),
+		SlaveData: slaveData,
+	}
+
+	return l.Set(req)
+}
+
 func (l *LinkService) list(kind string) ([]LinkMessage, error) {
 	req := &LinkMessage{}
 	flags := netlink.Request | netlink.Dump
@@ -214,12 +524,185 @@ func (l *LinkService) ListByKind(kind string) ([]LinkMessage, error) {
 	return l.list(kind)
 }
 
+// ListByMaster retrieves all interfaces enslaved to the master interface
+// with the given index.
+func (l *LinkService) ListByMaster(master uint32) ([]LinkMessage, error) {
+	req := &LinkMessage{
+		Attributes: &LinkAttributes{
+			Master: &master,
+		},
+	}
+
+	flags := netlink.Request | netlink.Dump
+	msgs, err := l.execute(req, unix.RTM_GETLINK, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	// Kernels without IFLA_MASTER dump filtering return all links, so
+	// always filter the response.
+	links := msgs[:0]
+	for _, m := range msgs {
+		if m.Attributes != nil && m.Attributes.Master != nil && *m.Attributes.Master == master {
+			links = append(links, m)
+		}
+	}
+
+	return links, nil
+}
+
 // List retrieves all interfaces.
 func (l *LinkService) List() ([]LinkMessage, error) {
 	return l.list("")
 }
 
+// ListFunc calls fn for each interface. The whole dump is received before
+// the first call, but the interfaces are decoded one at a time and are not
+// collected, so fn can filter them without retaining every LinkMessage. The
+// iteration stops at the first error returned by fn, which is returned
+// unless it is ErrStopList.
+func (l *LinkService) ListFunc(fn func(*LinkMessage) error) error {
+	flags := netlink.Request | netlink.Dump
+	return l.c.executeFunc(&LinkMessage{}, unix.RTM_GETLINK, flags, func(m Message) error {
+		return fn(m.(*LinkMessage))
+	})
+}
+
+// A LinkBrief is a lightweight record of an interface, holding only the
+// fields most commonly needed to inventory the interfaces of a system.
+type LinkBrief struct {
+	Index        uint32           // Unique interface index
+	Name         string           // Device name
+	MTU          uint32           // MTU of the device
+	HardwareAddr net.HardwareAddr // Interface L2 address
+	Flags        uint32           // Device flags, see netdevice(7)
+	QueueDisc    string           // Root queueing discipline
+}
+
+// unmarshalBinary unmarshals the header of a link message and only the
+// attributes of the LinkBrief, skipping all others.
+func (b *LinkBrief) unmarshalBinary(data []byte) error {
+	if len(data) < unix.SizeofIfInfomsg {
+		return errInvalidLinkMessage
+	}
+
+	b.Index = nativeEndian.Uint32(data[4:8])
+	b.Flags = nativeEndian.Uint32(data[8:12])
+
+	ad, err := netlink.NewAttributeDecoder(data[unix.SizeofIfInfomsg:])
+	if err != nil {
+		return err
+	}
+	ad.ByteOrder = nativeEndian
+
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_IFNAME:
+			b.Name = ad.String()
+		case unix.IFLA_MTU:
+			b.MTU = ad.Uint32()
+		case unix.IFLA_ADDRESS:
+			b.HardwareAddr = ad.Bytes()
+		case unix.IFLA_QDISC:
+			b.QueueDisc = ad.String()
+		}
+	}
+
+	return ad.Err()
+}
+
+// ListBrief retrieves a LinkBrief for all interfaces. The kernel is asked to
+// skip the interface statistics and only the attributes of the LinkBrief are
+// decoded, which makes it considerably cheaper than List on systems with
+// many interfaces.
+func (l *LinkService) ListBrief() ([]LinkBrief, error) {
+	hdr, err := (&LinkMessage{}).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	ae := netlink.NewAttributeEncoder()
+	ae.ByteOrder = nativeEndian
+	ae.Uint32(unix.IFLA_EXT_MASK, unix.RTEXT_FILTER_SKIP_STATS)
+	attrs, err := ae.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := l.c.execute(netlink.Message{
+		Header: netlink.Header{
+			Type:  unix.RTM_GETLINK,
+			Flags: netlink.Request | netlink.Dump,
+		},
+		Data: append(hdr, attrs...),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]LinkBrief, 0, len(msgs))
+	for _, m := range msgs {
+		if m.Header.Type != unix.RTM_NEWLINK {
+			continue
+		}
+
+		var b LinkBrief
+		if err := b.unmarshalBinary(m.Data); err != nil {
+			return nil, err
+		}
+		links = append(links, b)
+	}
+
+	return links, nil
+}
+
+// A CarrierEvent reports a change of the carrier state of an interface.
+type CarrierEvent struct {
+	Index uint32
+	Up    bool
+}
+
+// CarrierEvents filters a stream of Messages, such as the ones received on a
+// Conn joined to the RTNLGRP_LINK group, down to carrier changes. The carrier
+// state of an interface is compared across its successive link messages, and
+// a CarrierEvent is sent whenever it differs from the previous one. The first
+// link message seen for an interface only records its state.
+//
+// The returned channel is closed once msgs is closed.
+func CarrierEvents(msgs <-chan Message) <-chan CarrierEvent {
+	events := make(chan CarrierEvent)
+
+	go func() {
+		defer close(events)
+
+		carrier := make(map[uint32]bool)
+		for m := range msgs {
+			lm, ok := m.(*LinkMessage)
+			if !ok || lm.Attributes == nil || lm.Attributes.Carrier == nil {
+				continue
+			}
+
+			up := *lm.Attributes.Carrier == 1
+			prev, seen := carrier[lm.Index]
+			carrier[lm.Index] = up
+			if seen && prev != up {
+				events <- CarrierEvent{Index: lm.Index, Up: up}
+			}
+		}
+	}()
+
+	return events
+}
+
 // LinkAttributes contains all attributes for an interface.
+//
+// Not every attribute reported by the kernel can be sent back. The fields
+// tagged "(read only)" below, as well as AltNames, Carrier and its counters,
+// Index, LinkMode, NetDevGroup, the PhysPort and PhysSwitch identifiers, Stats
+// and Stats64, are decoded but never encoded, so LinkService.New and
+// LinkService.Set ignore them. AltNames are changed with
+// LinkService.AddAltName and DelAltName, and the GSO and GRO limits with
+// LinkService.SetGSO.
 type LinkAttributes struct {
 	Address          net.HardwareAddr // Interface L2 address
 	Alias            *string          // Interface alias name
@@ -247,6 +730,36 @@ type LinkAttributes struct {
 	Type             uint32           // Link type
 	XDP              *LinkXDP         // Express Data Patch Information
 	NetNS            *NetNS           // Interface network namespace
+	LinkNetNSID      *int32           // Network namespace identifier of the peer or underlying interface (read only)
+	Inet4            *LinkInet4       // IPv4 specific interface configuration (read only)
+	Inet6            *LinkInet6       // IPv6 specific interface configuration (read only)
+	AddrGenMode      *IN6AddrGenMode  // IPv6 address generation mode to configure (write only, see Inet6)
+	GSOMaxSegs       *uint32          // Maximum number of segments of a GSO packet (read only, see LinkService.SetGSO)
+	GSOMaxSize       *uint32          // Maximum size of a GSO packet (read only, see LinkService.SetGSO)
+	GROMaxSize       *uint32          // Maximum size of a GRO packet (read only, see LinkService.SetGSO)
+	GSOIPv4MaxSize   *uint32          // Maximum size of an IPv4 GSO packet (read only, see LinkService.SetGSO)
+	GROIPv4MaxSize   *uint32          // Maximum size of an IPv4 GRO packet (read only, see LinkService.SetGSO)
+
+	// UnknownAttrs holds the types of the attributes that were reported by
+	// the kernel but are unknown to this package, in the order they were
+	// encountered. It helps to diagnose why a value is missing when a newer
+	// kernel reports attributes this package does not know yet. It is only
+	// filled in after RecordUnknownAttrs(true) (read only).
+	UnknownAttrs []uint16
+}
+
+// recordUnknownAttrs enables filling in LinkAttributes.UnknownAttrs.
+var recordUnknownAttrs bool
+
+// RecordUnknownAttrs enables or disables recording the link attributes
+// unknown to this package in LinkAttributes.UnknownAttrs. It is disabled by
+// default. Attributes which are known but not decoded are not recorded, and
+// neither are the attributes nested in IFLA_LINKINFO, which are decoded by
+// the LinkDriver of the interface.
+//
+// This function is not threadsafe. This should not be used after Dial
+func RecordUnknownAttrs(enable bool) {
+	recordUnknownAttrs = enable
 }
 
 // OperationalState represents an interface's operational state.
@@ -266,7 +779,7 @@ const (
 )
 
 // unmarshalBinary unmarshals the contents of a byte slice into a LinkMessage.
-func (a *LinkAttributes) decode(ad *netlink.AttributeDecoder) error {
+func (a *LinkAttributes) decode(ad *netlink.AttributeDecoder, family uint16) error {
 	for ad.Next() {
 		switch ad.Type() {
 		case unix.IFLA_UNSPEC:
@@ -307,6 +820,9 @@ func (a *LinkAttributes) decode(ad *netlink.AttributeDecoder) error {
 			a.Name = ad.String()
 		case unix.IFLA_LINK:
 			a.Type = ad.Uint32()
+		case unix.IFLA_LINK_NETNSID:
+			v := ad.Int32()
+			a.LinkNetNSID = &v
 		case unix.IFLA_LINKINFO:
 			a.Info = &LinkInfo{}
 			ad.Nested(a.Info.decode)
@@ -344,23 +860,67 @@ func (a *LinkAttributes) decode(ad *netlink.AttributeDecoder) error {
 		case unix.IFLA_TXQLEN:
 			v := ad.Uint32()
 			a.TxQueueLen = &v
+		case unix.IFLA_GSO_MAX_SEGS:
+			v := ad.Uint32()
+			a.GSOMaxSegs = &v
+		case unix.IFLA_GSO_MAX_SIZE:
+			v := ad.Uint32()
+			a.GSOMaxSize = &v
+		case unix.IFLA_GRO_MAX_SIZE:
+			v := ad.Uint32()
+			a.GROMaxSize = &v
+		case unix.IFLA_GSO_IPV4_MAX_SIZE:
+			v := ad.Uint32()
+			a.GSOIPv4MaxSize = &v
+		case unix.IFLA_GRO_IPV4_MAX_SIZE:
+			v := ad.Uint32()
+			a.GROIPv4MaxSize = &v
 		case unix.IFLA_XDP:
 			a.XDP = &LinkXDP{}
 			ad.Nested(a.XDP.decode)
 		case unix.IFLA_PROP_LIST:
-			// read nested encoded property list
-			nad, err := netlink.NewAttributeDecoder(ad.Bytes())
-			if err != nil {
-				return err
+			ad.Nested(a.decodePropList)
+		case unix.IFLA_AF_SPEC:
+			// Only the per family blocks of AF_UNSPEC, AF_INET and AF_INET6
+			// messages are decoded. Other families, such as AF_BRIDGE, use
+			// the same nested types for their own attributes.
+			switch family {
+			case unix.AF_UNSPEC, unix.AF_INET, unix.AF_INET6:
+				ad.Nested(a.decodeAFSpec)
 			}
-			for nad.Next() {
-				if nad.Type() == unix.IFLA_ALT_IFNAME {
-					a.AltNames = append(a.AltNames, nad.String())
-				}
+		default:
+			// All attributes up to IFLA_DPLL_PIN are known, even if they
+			// are not decoded.
+			if recordUnknownAttrs && ad.Type() > unix.IFLA_DPLL_PIN {
+				a.UnknownAttrs = append(a.UnknownAttrs, ad.Type())
 			}
 		}
 	}
 
+	return ad.Err()
+}
+
+// Statistics returns the interface statistics, preferring the 64 bits
+// version. If only the 32 bits statistics were reported, they are widened to
+// a LinkStats64. Statistics returns nil if neither were reported.
+func (a *LinkAttributes) Statistics() *LinkStats64 {
+	switch {
+	case a.Stats64 != nil:
+		return a.Stats64
+	case a.Stats != nil:
+		return a.Stats.widen()
+	default:
+		return nil
+	}
+}
+
+// decodePropList decodes the nested IFLA_PROP_LIST property list.
+func (a *LinkAttributes) decodePropList(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		if ad.Type() == unix.IFLA_ALT_IFNAME {
+			a.AltNames = append(a.AltNames, ad.String())
+		}
+	}
 	return nil
 }
 
@@ -386,6 +946,10 @@ func (a *LinkAttributes) encode(ae *netlink.AttributeEncoder) error {
 		ae.Uint32(unix.IFLA_MTU, a.MTU)
 	}
 
+	if a.TxQueueLen != nil {
+		ae.Uint32(unix.IFLA_TXQLEN, *a.TxQueueLen)
+	}
+
 	if len(a.Address) != 0 {
 		ae.Bytes(unix.IFLA_ADDRESS, a.Address)
 	}
@@ -437,6 +1001,10 @@ func (a *LinkAttributes) encode(ae *netlink.AttributeEncoder) error {
 		ae.Uint32(a.NetNS.value())
 	}
 
+	if a.AddrGenMode != nil {
+		ae.Nested(unix.IFLA_AF_SPEC, a.encodeAFSpec)
+	}
+
 	return nil
 }
 
@@ -516,6 +1084,40 @@ func (a *LinkStats) unmarshalBinary(b []byte) error {
 	return nil
 }
 
+// widen returns the statistics as a LinkStats64.
+func (a *LinkStats) widen() *LinkStats64 {
+	return &LinkStats64{
+		RXPackets:  uint64(a.RXPackets),
+		TXPackets:  uint64(a.TXPackets),
+		RXBytes:    uint64(a.RXBytes),
+		TXBytes:    uint64(a.TXBytes),
+		RXErrors:   uint64(a.RXErrors),
+		TXErrors:   uint64(a.TXErrors),
+		RXDropped:  uint64(a.RXDropped),
+		TXDropped:  uint64(a.TXDropped),
+		Multicast:  uint64(a.Multicast),
+		Collisions: uint64(a.Collisions),
+
+		RXLengthErrors: uint64(a.RXLengthErrors),
+		RXOverErrors:   uint64(a.RXOverErrors),
+		RXCRCErrors:    uint64(a.RXCRCErrors),
+		RXFrameErrors:  uint64(a.RXFrameErrors),
+		RXFIFOErrors:   uint64(a.RXFIFOErrors),
+		RXMissedErrors: uint64(a.RXMissedErrors),
+
+		TXAbortedErrors:   uint64(a.TXAbortedErrors),
+		TXCarrierErrors:   uint64(a.TXCarrierErrors),
+		TXFIFOErrors:      uint64(a.TXFIFOErrors),
+		TXHeartbeatErrors: uint64(a.TXHeartbeatErrors),
+		TXWindowErrors:    uint64(a.TXWindowErrors),
+
+		RXCompressed: uint64(a.RXCompressed),
+		TXCompressed: uint64(a.TXCompressed),
+
+		RXNoHandler: uint64(a.RXNoHandler),
+	}
+}
+
 // LinkStats64 contains packet statistics
 type LinkStats64 struct {
 	RXPackets  uint64 // total packets received
@@ -665,6 +1267,52 @@ type LinkSlaveDriver interface {
 	Slave()
 }
 
+// LinkDriverCapabilities defines a LinkDriver which declares whether its
+// links can be enslaved to a master or act as a master themselves. Drivers
+// that do not implement it are assumed to support both.
+type LinkDriverCapabilities interface {
+	LinkDriver
+
+	// CanBeSlave reports whether a link of this kind can be enslaved
+	CanBeSlave() bool
+
+	// CanHaveSlaves reports whether a link of this kind can be a master
+	CanHaveSlaves() bool
+}
+
+// checkEnslave verifies that link can be enslaved to master according to the
+// capabilities declared by their registered drivers.
+func checkEnslave(link, master *LinkMessage) error {
+	if d, ok := linkCapabilities(link); ok && !d.CanBeSlave() {
+		return fmt.Errorf("rtnetlink: %s link %d cannot be enslaved", d.Kind(), link.Index)
+	}
+	if d, ok := linkCapabilities(master); ok && !d.CanHaveSlaves() {
+		return fmt.Errorf("rtnetlink: %s link %d cannot have slaves", d.Kind(), master.Index)
+	}
+	return nil
+}
+
+// hasLinkCapabilities reports whether any registered driver implements
+// LinkDriverCapabilities.
+func hasLinkCapabilities() bool {
+	for _, d := range registeredDrivers {
+		if _, ok := d.(LinkDriverCapabilities); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// linkCapabilities returns the registered driver of the link kind if it
+// implements LinkDriverCapabilities.
+func linkCapabilities(m *LinkMessage) (LinkDriverCapabilities, bool) {
+	if m.Attributes == nil || m.Attributes.Info == nil {
+		return nil, false
+	}
+	d, ok := registeredDrivers[m.Attributes.Info.Kind].(LinkDriverCapabilities)
+	return d, ok
+}
+
 // LinkDriverVerifier defines a LinkDriver with Verify method
 type LinkDriverVerifier interface {
 	LinkDriver
@@ -675,6 +1323,11 @@ type LinkDriverVerifier interface {
 }
 
 // LinkData implements the default LinkDriver interface for not registered drivers
+//
+// It is used to decode the raw IFLA_INFO_DATA of kinds without a registered
+// driver, and can equally be used to create or modify links of any kind by
+// setting Name to the kind and Data to the already encoded IFLA_INFO_DATA
+// attributes. Set Slave to send Data as IFLA_INFO_SLAVE_DATA instead.
 type LinkData struct {
 	Name  string
 	Data  []byte
@@ -807,3 +1460,262 @@ func (xdp *LinkXDP) encode(ae *netlink.AttributeEncoder) error {
 	// https://elixir.bootlin.com/linux/v5.10.15/source/net/core/rtnetlink.c#L2894
 	return nil
 }
+
+// decodeAFSpec decodes the address family specific attributes of an
+// interface.
+func (a *LinkAttributes) decodeAFSpec(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.AF_INET:
+			a.Inet4 = &LinkInet4{}
+			ad.Nested(a.Inet4.decode)
+		case unix.AF_INET6:
+			a.Inet6 = &LinkInet6{}
+			ad.Nested(a.Inet6.decode)
+		}
+	}
+	return nil
+}
+
+// encodeAFSpec encodes the address family specific attributes of an
+// interface.
+func (a *LinkAttributes) encodeAFSpec(ae *netlink.AttributeEncoder) error {
+	ae.Nested(unix.AF_INET6, func(nae *netlink.AttributeEncoder) error {
+		nae.Uint8(unix.IFLA_INET6_ADDR_GEN_MODE, uint8(*a.AddrGenMode))
+		return nil
+	})
+	return nil
+}
+
+// LinkInet4 holds the per-interface IPv4 configuration, the values of the
+// net.ipv4.conf.<interface> sysctls. Kernels only report the values they
+// know of, so newer fields may remain zero on older kernels.
+type LinkInet4 struct {
+	Forwarding                      int32
+	MCForwarding                    int32
+	ProxyARP                        int32
+	AcceptRedirects                 int32
+	SecureRedirects                 int32
+	SendRedirects                   int32
+	SharedMedia                     int32
+	RPFilter                        int32
+	AcceptSourceRoute               int32
+	BootpRelay                      int32
+	LogMartians                     int32
+	Tag                             int32
+	ARPFilter                       int32
+	MediumID                        int32
+	NoXfrm                          int32
+	NoPolicy                        int32
+	ForceIGMPVersion                int32
+	ARPAnnounce                     int32
+	ARPIgnore                       int32
+	PromoteSecondaries              int32
+	ARPAccept                       int32
+	ARPNotify                       int32
+	AcceptLocal                     int32
+	SrcValidMark                    int32
+	ProxyARPPVLAN                   int32
+	RouteLocalnet                   int32
+	IGMPv2UnsolicitedReportInterval int32
+	IGMPv3UnsolicitedReportInterval int32
+	IgnoreRoutesWithLinkdown        int32
+	DropUnicastInL2Multicast        int32
+	DropGratuitousARP               int32
+	BCForwarding                    int32
+	ARPEvictNoCarrier               int32
+}
+
+func (i *LinkInet4) decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_INET_CONF:
+			ad.Do(i.unmarshalConf)
+		}
+	}
+	return nil
+}
+
+// unmarshalConf decodes the IFLA_INET_CONF array, which holds one 32 bit
+// value per IPV4_DEVCONF_* identifier starting at IPV4_DEVCONF_FORWARDING.
+func (i *LinkInet4) unmarshalConf(b []byte) error {
+	if len(b)%4 != 0 {
+		return fmt.Errorf("rtnetlink: invalid IFLA_INET_CONF length: %d", len(b))
+	}
+
+	conf := []*int32{
+		&i.Forwarding,
+		&i.MCForwarding,
+		&i.ProxyARP,
+		&i.AcceptRedirects,
+		&i.SecureRedirects,
+		&i.SendRedirects,
+		&i.SharedMedia,
+		&i.RPFilter,
+		&i.AcceptSourceRoute,
+		&i.BootpRelay,
+		&i.LogMartians,
+		&i.Tag,
+		&i.ARPFilter,
+		&i.MediumID,
+		&i.NoXfrm,
+		&i.NoPolicy,
+		&i.ForceIGMPVersion,
+		&i.ARPAnnounce,
+		&i.ARPIgnore,
+		&i.PromoteSecondaries,
+		&i.ARPAccept,
+		&i.ARPNotify,
+		&i.AcceptLocal,
+		&i.SrcValidMark,
+		&i.ProxyARPPVLAN,
+		&i.RouteLocalnet,
+		&i.IGMPv2UnsolicitedReportInterval,
+		&i.IGMPv3UnsolicitedReportInterval,
+		&i.IgnoreRoutesWithLinkdown,
+		&i.DropUnicastInL2Multicast,
+		&i.DropGratuitousARP,
+		&i.BCForwarding,
+		&i.ARPEvictNoCarrier,
+	}
+
+	for n := 0; n < len(b)/4 && n < len(conf); n++ {
+		*conf[n] = int32(nativeEndian.Uint32(b[n*4:]))
+	}
+
+	return nil
+}
+
+// IN6AddrGenMode specifies how the IPv6 link-local address and the SLAAC
+// addresses of an interface are generated.
+type IN6AddrGenMode uint8
+
+const (
+	IN6AddrGenModeEUI64         IN6AddrGenMode = unix.IN6_ADDR_GEN_MODE_EUI64          // derived from the L2 address
+	IN6AddrGenModeNone          IN6AddrGenMode = unix.IN6_ADDR_GEN_MODE_NONE           // no addresses are generated
+	IN6AddrGenModeStablePrivacy IN6AddrGenMode = unix.IN6_ADDR_GEN_MODE_STABLE_PRIVACY // stable privacy addresses (RFC 7217)
+	IN6AddrGenModeRandom        IN6AddrGenMode = unix.IN6_ADDR_GEN_MODE_RANDOM         // stable privacy addresses with a random secret
+)
+
+func (m IN6AddrGenMode) String() string {
+	switch m {
+	case IN6AddrGenModeEUI64:
+		return "eui64"
+	case IN6AddrGenModeNone:
+		return "none"
+	case IN6AddrGenModeStablePrivacy:
+		return "stable_secret"
+	case IN6AddrGenModeRandom:
+		return "random"
+	default:
+		return fmt.Sprintf("unknown IN6AddrGenMode value (%d)", m)
+	}
+}
+
+// LinkInet6 holds the per-interface IPv6 configuration.
+type LinkInet6 struct {
+	Flags       uint32         // IF_RA_* and IF_READY flags of the interface
+	Token       net.IP         // Interface identifier used to generate addresses, if set
+	AddrGenMode IN6AddrGenMode // How addresses of the interface are generated
+	Conf        *LinkInet6Conf // The values of the net.ipv6.conf.<interface> sysctls
+}
+
+func (i *LinkInet6) decode(ad *netlink.AttributeDecoder) error {
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.IFLA_INET6_FLAGS:
+			i.Flags = ad.Uint32()
+		case unix.IFLA_INET6_TOKEN:
+			ad.Do(decodeIP(&i.Token))
+		case unix.IFLA_INET6_ADDR_GEN_MODE:
+			i.AddrGenMode = IN6AddrGenMode(ad.Uint8())
+		case unix.IFLA_INET6_CONF:
+			i.Conf = &LinkInet6Conf{}
+			ad.Do(i.Conf.unmarshalBinary)
+		}
+	}
+	return nil
+}
+
+// LinkInet6Conf holds the leading values of the net.ipv6.conf.<interface>
+// sysctls. Kernels only report the values they know of, so newer fields may
+// remain zero on older kernels.
+type LinkInet6Conf struct {
+	Forwarding            int32
+	HopLimit              int32
+	MTU                   int32
+	AcceptRA              int32
+	AcceptRedirects       int32
+	Autoconf              int32
+	DADTransmits          int32
+	RtrSolicits           int32
+	RtrSolicitInterval    int32 // in milliseconds
+	RtrSolicitDelay       int32 // in milliseconds
+	UseTempAddr           int32
+	TempValidLft          int32
+	TempPreferedLft       int32
+	RegenMaxRetry         int32
+	MaxDesyncFactor       int32
+	MaxAddresses          int32
+	ForceMLDVersion       int32
+	AcceptRADefRtr        int32
+	AcceptRAPInfo         int32
+	AcceptRARtrPref       int32
+	RtrProbeInterval      int32 // in milliseconds
+	AcceptRARtInfoMaxPLen int32
+	ProxyNDP              int32
+	OptimisticDAD         int32
+	AcceptSourceRoute     int32
+	MCForwarding          int32
+	DisableIPv6           int32
+	AcceptDAD             int32
+	ForceTLLAO            int32
+	NDiscNotify           int32
+}
+
+// unmarshalBinary decodes the IFLA_INET6_CONF array, which holds one 32 bit
+// value per DEVCONF_* identifier starting at DEVCONF_FORWARDING.
+func (c *LinkInet6Conf) unmarshalBinary(b []byte) error {
+	if len(b)%4 != 0 {
+		return fmt.Errorf("rtnetlink: invalid IFLA_INET6_CONF length: %d", len(b))
+	}
+
+	conf := []*int32{
+		&c.Forwarding,
+		&c.HopLimit,
+		&c.MTU,
+		&c.AcceptRA,
+		&c.AcceptRedirects,
+		&c.Autoconf,
+		&c.DADTransmits,
+		&c.RtrSolicits,
+		&c.RtrSolicitInterval,
+		&c.RtrSolicitDelay,
+		&c.UseTempAddr,
+		&c.TempValidLft,
+		&c.TempPreferedLft,
+		&c.RegenMaxRetry,
+		&c.MaxDesyncFactor,
+		&c.MaxAddresses,
+		&c.ForceMLDVersion,
+		&c.AcceptRADefRtr,
+		&c.AcceptRAPInfo,
+		&c.AcceptRARtrPref,
+		&c.RtrProbeInterval,
+		&c.AcceptRARtInfoMaxPLen,
+		&c.ProxyNDP,
+		&c.OptimisticDAD,
+		&c.AcceptSourceRoute,
+		&c.MCForwarding,
+		&c.DisableIPv6,
+		&c.AcceptDAD,
+		&c.ForceTLLAO,
+		&c.NDiscNotify,
+	}
+
+	for n := 0; n < len(b)/4 && n < len(conf); n++ {
+		*conf[n] = int32(nativeEndian.Uint32(b[n*4:]))
+	}
+
+	return nil
+}
@@ -4,7 +4,10 @@
 package rtnetlink
 
 import (
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cilium/ebpf"
 	"github.com/cilium/ebpf/asm"
@@ -240,6 +243,168 @@ func TestLinkXDPReplace(t *testing.T) {
 	}
 }
 
+// createVeth creates a veth pair with the given interface indices. The
+// veth driver lives in the driver package, so its data is encoded here.
+func createVeth(tb testing.TB, conn *Conn, index, peerIndex uint32) {
+	tb.Helper()
+
+	peer, err := (&LinkMessage{Index: peerIndex}).MarshalBinary()
+	if err != nil {
+		tb.Fatalf("failed to encode veth peer: %v", err)
+	}
+
+	ae := netlink.NewAttributeEncoder()
+	ae.Bytes(1, peer) // VETH_INFO_PEER
+	data, err := ae.Encode()
+	if err != nil {
+		tb.Fatalf("failed to encode veth data: %v", err)
+	}
+
+	if err := conn.Link.New(&LinkMessage{
+		Index: index,
+		Attributes: &LinkAttributes{
+			Info: &LinkInfo{
+				Kind: "veth",
+				Data: &LinkData{Name: "veth", Data: data},
+			},
+		},
+	}); err != nil {
+		tb.Fatalf("failed to create veth pair: %v", err)
+	}
+}
+
+func TestLinkListByMaster(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	const (
+		bridgeIndex = 1400
+		veth1Index  = 1401
+		veth2Index  = 1403
+	)
+
+	if err := conn.Link.New(&LinkMessage{
+		Index: bridgeIndex,
+		Attributes: &LinkAttributes{
+			Info: &LinkInfo{
+				Kind: "bridge",
+				Data: &LinkData{Name: "bridge"},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("failed to create bridge: %v", err)
+	}
+	defer conn.Link.Delete(bridgeIndex)
+
+	for _, index := range []uint32{veth1Index, veth2Index} {
+		createVeth(t, conn, index, index+1)
+		defer conn.Link.Delete(index)
+
+		if err := conn.Link.Enslave(index, bridgeIndex, nil); err != nil {
+			t.Fatalf("failed to enslave veth %d: %v", index, err)
+		}
+	}
+
+	links, err := conn.Link.ListByMaster(bridgeIndex)
+	if err != nil {
+		t.Fatalf("failed to list links by master: %v", err)
+	}
+
+	var got []uint32
+	for _, l := range links {
+		got = append(got, l.Index)
+	}
+
+	if want := []uint32{veth1Index, veth2Index}; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected members of bridge, want: %v, got: %v", want, got)
+	}
+}
+
+func TestLinkRename(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	const vethIndex = 1500
+
+	createVeth(t, conn, vethIndex, vethIndex+1)
+	defer conn.Link.Delete(vethIndex)
+
+	if err := conn.Link.Rename(vethIndex, ""); err == nil {
+		t.Fatal("expected an error for an empty name, but none occurred")
+	}
+	if err := conn.Link.Rename(vethIndex, "averyveryverylongname"); err == nil {
+		t.Fatal("expected an error for a name exceeding IFNAMSIZ, but none occurred")
+	}
+
+	if err := conn.Link.Rename(vethIndex, "renamed0"); err != nil {
+		t.Fatalf("failed to rename link: %v", err)
+	}
+
+	link, err := conn.Link.Get(vethIndex)
+	if err != nil {
+		t.Fatalf("failed to get link: %v", err)
+	}
+	if want, got := "renamed0", link.Attributes.Name; want != got {
+		t.Fatalf("unexpected link name, want: %q, got: %q", want, got)
+	}
+}
+
+func TestLinkGROIPv4MaxSize(t *testing.T) {
+	testutils.SkipOnOldKernel(t, "6.3", "IFLA_GRO_IPV4_MAX_SIZE support")
+
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	const vethIndex = 1700
+
+	createVeth(t, conn, vethIndex, vethIndex+1)
+	defer conn.Link.Delete(vethIndex)
+
+	size := uint32(32768)
+	if err := conn.Link.SetGSO(vethIndex, &LinkGSO{
+		GROIPv4MaxSize: &size,
+	}); err != nil {
+		t.Fatalf("failed to set IPv4 GRO max size: %v", err)
+	}
+
+	link, err := conn.Link.Get(vethIndex)
+	if err != nil {
+		t.Fatalf("failed to get link: %v", err)
+	}
+	if link.Attributes.GROIPv4MaxSize == nil {
+		t.Fatal("IPv4 GRO max size was not reported")
+	}
+	if want, got := size, *link.Attributes.GROIPv4MaxSize; want != got {
+		t.Fatalf("unexpected IPv4 GRO max size, want: %d, got: %d", want, got)
+	}
+
+	// The decoded limits are read only, so sending back a modified link
+	// must not change them. The decoded XDP state cannot be sent back as
+	// is, so leave it out.
+	other := size / 2
+	link.Attributes.GROIPv4MaxSize = &other
+	link.Attributes.XDP = nil
+	if err := conn.Link.Set(&link); err != nil {
+		t.Fatalf("failed to set link: %v", err)
+	}
+	link, err = conn.Link.Get(vethIndex)
+	if err != nil {
+		t.Fatalf("failed to get link: %v", err)
+	}
+	if want, got := size, *link.Attributes.GROIPv4MaxSize; want != got {
+		t.Fatalf("unexpected IPv4 GRO max size after Set, want: %d, got: %d", want, got)
+	}
+}
+
 func TestLinkListByKind(t *testing.T) {
 	if err := rlimit.RemoveMemlock(); err != nil {
 		t.Fatal(err)
@@ -260,3 +425,265 @@ func TestLinkListByKind(t *testing.T) {
 		t.Fatalf("LinkListByKind() found %d links with impossible kind", len(links))
 	}
 }
+
+func TestLinkCarrierEvents(t *testing.T) {
+	ns := testutils.NetNS(t)
+
+	conn, err := Dial(&netlink.Config{NetNS: ns})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	mon, err := Dial(&netlink.Config{NetNS: ns})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer mon.Close()
+
+	const vethIndex = 1500
+
+	createVeth(t, conn, vethIndex, vethIndex+1)
+	defer conn.Link.Delete(vethIndex)
+
+	if err := mon.JoinGroup(unix.RTNLGRP_LINK); err != nil {
+		t.Fatalf("failed to join link group: %v", err)
+	}
+	if err := mon.SetReadDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		t.Fatalf("failed to set read deadline: %v", err)
+	}
+
+	msgs := make(chan Message)
+	go func() {
+		defer close(msgs)
+		for {
+			rtmsgs, _, err := mon.Receive()
+			if err != nil {
+				return
+			}
+			for _, m := range rtmsgs {
+				msgs <- m
+			}
+		}
+	}()
+	events := CarrierEvents(msgs)
+
+	setFlags := func(index, flags uint32) {
+		t.Helper()
+
+		if err := conn.Link.Set(&LinkMessage{
+			Family: unix.AF_UNSPEC,
+			Index:  index,
+			Flags:  flags,
+			Change: unix.IFF_UP,
+		}); err != nil {
+			t.Fatalf("failed to set flags of link %d: %v", index, err)
+		}
+	}
+	next := func() CarrierEvent {
+		t.Helper()
+
+		for e := range events {
+			if e.Index == vethIndex {
+				return e
+			}
+		}
+		t.Fatal("monitor stopped before a carrier event was received")
+		return CarrierEvent{}
+	}
+
+	// The carrier of a veth follows the administrative state of its peer.
+	setFlags(vethIndex, unix.IFF_UP)
+	setFlags(vethIndex+1, unix.IFF_UP)
+	if e := next(); !e.Up {
+		t.Fatalf("expected carrier up event, got %+v", e)
+	}
+
+	setFlags(vethIndex+1, 0)
+	if e := next(); e.Up {
+		t.Fatalf("expected carrier down event, got %+v", e)
+	}
+}
+
+func TestLinkListBrief(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	links, err := conn.Link.ListBrief()
+	if err != nil {
+		t.Fatalf("failed to list brief links: %v", err)
+	}
+	if len(links) != 1 {
+		t.Fatalf("expected only the loopback interface, got %d links", len(links))
+	}
+
+	full, err := conn.Link.Get(lo)
+	if err != nil {
+		t.Fatalf("failed to get loopback: %v", err)
+	}
+
+	want := LinkBrief{
+		Index:        full.Index,
+		Name:         full.Attributes.Name,
+		MTU:          full.Attributes.MTU,
+		HardwareAddr: full.Attributes.Address,
+		Flags:        full.Flags,
+		QueueDisc:    full.Attributes.QueueDisc,
+	}
+	if !reflect.DeepEqual(want, links[0]) {
+		t.Fatalf("unexpected brief link:\n want: %+v\n  got: %+v", want, links[0])
+	}
+}
+
+func TestLinkSetMultipleAttributes(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	const vethIndex = 1500
+
+	createVeth(t, conn, vethIndex, vethIndex+1)
+	defer conn.Link.Delete(vethIndex)
+
+	var (
+		alias      = "uplink"
+		txQueueLen = uint32(500)
+	)
+	req := &LinkMessage{
+		Family: unix.AF_UNSPEC,
+		Index:  vethIndex,
+		Flags:  unix.IFF_UP,
+		Change: unix.IFF_UP,
+		Attributes: &LinkAttributes{
+			MTU:        1400,
+			Alias:      &alias,
+			TxQueueLen: &txQueueLen,
+		},
+	}
+
+	// Applying the same request twice must succeed and leave the link as is.
+	for i := 0; i < 2; i++ {
+		if err := conn.Link.Set(req); err != nil {
+			t.Fatalf("failed to set link attributes: %v", err)
+		}
+
+		got, err := conn.Link.Get(vethIndex)
+		if err != nil {
+			t.Fatalf("failed to get link: %v", err)
+		}
+
+		if got.Flags&unix.IFF_UP == 0 {
+			t.Fatal("expected link to be up")
+		}
+		if got.Attributes.MTU != 1400 {
+			t.Fatalf("unexpected MTU: want 1400, got %d", got.Attributes.MTU)
+		}
+		if got.Attributes.Alias == nil || *got.Attributes.Alias != alias {
+			t.Fatalf("unexpected alias: want %q, got %v", alias, got.Attributes.Alias)
+		}
+		if got.Attributes.TxQueueLen == nil || *got.Attributes.TxQueueLen != txQueueLen {
+			t.Fatalf("unexpected transmit queue length: want %d, got %v", txQueueLen, got.Attributes.TxQueueLen)
+		}
+	}
+}
+
+func TestLinkGetByName(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	m, err := conn.Link.GetByName("lo")
+	if err != nil {
+		t.Fatalf("failed to get link by name: %v", err)
+	}
+	if want, got := lo, m.Index; want != got {
+		t.Fatalf("unexpected link index, want: %d, got: %d", want, got)
+	}
+
+	if _, err := conn.Link.GetByName("rtnl-missing0"); !errors.Is(err, unix.ENODEV) {
+		t.Fatalf("expected ENODEV for a missing link, got: %v", err)
+	}
+}
+
+func TestLinkSetAddrGenMode(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	const vethIndex = 1550
+
+	createVeth(t, conn, vethIndex, vethIndex+1)
+	defer conn.Link.Delete(vethIndex)
+
+	for _, mode := range []IN6AddrGenMode{IN6AddrGenModeNone, IN6AddrGenModeRandom, IN6AddrGenModeEUI64} {
+		mode := mode
+		if err := conn.Link.Set(&LinkMessage{
+			Index: vethIndex,
+			Attributes: &LinkAttributes{
+				AddrGenMode: &mode,
+			},
+		}); err != nil {
+			t.Fatalf("failed to set address generation mode %s: %v", mode, err)
+		}
+
+		got, err := conn.Link.Get(vethIndex)
+		if err != nil {
+			t.Fatalf("failed to get link: %v", err)
+		}
+		if got.Attributes.Inet6 == nil {
+			t.Fatal("link has no IPv6 configuration")
+		}
+		if want, got := mode, got.Attributes.Inet6.AddrGenMode; want != got {
+			t.Fatalf("unexpected address generation mode, want: %s, got: %s", want, got)
+		}
+	}
+}
+
+func TestLinkAltName(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	const (
+		vethIndex = 1560
+		altName   = "rtnl-alternative-name-longer-than-ifnamsiz"
+	)
+
+	createVeth(t, conn, vethIndex, vethIndex+1)
+	defer conn.Link.Delete(vethIndex)
+
+	if err := conn.Link.AddAltName(vethIndex, altName); err != nil {
+		t.Fatalf("failed to add alternative name: %v", err)
+	}
+
+	m, err := conn.Link.GetByName(altName)
+	if err != nil {
+		t.Fatalf("failed to get link by alternative name: %v", err)
+	}
+	if want, got := []string{altName}, m.Attributes.AltNames; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected alternative names, want: %v, got: %v", want, got)
+	}
+
+	if err := conn.Link.DelAltName(vethIndex, altName); err != nil {
+		t.Fatalf("failed to delete alternative name: %v", err)
+	}
+
+	got, err := conn.Link.Get(vethIndex)
+	if err != nil {
+		t.Fatalf("failed to get link: %v", err)
+	}
+	if len(got.Attributes.AltNames) != 0 {
+		t.Fatalf("unexpected alternative names after delete: %v", got.Attributes.AltNames)
+	}
+}
@@ -3,15 +3,24 @@ package rtnetlink
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"reflect"
 	"testing"
 
+	"github.com/mdlayher/netlink"
 	"golang.org/x/sys/unix"
 )
 
 func TestLinkMessageMarshalBinary(t *testing.T) {
 	skipBigEndian(t)
 
+	var (
+		gsoMaxSize     uint32 = 65536
+		groIPv4MaxSize uint32 = 131072
+		txQueueLen     uint32 = 500
+		addrGenMode           = IN6AddrGenModeNone
+	)
+
 	tests := []struct {
 		name string
 		m    Message
@@ -141,6 +150,54 @@ func TestLinkMessageMarshalBinary(t *testing.T) {
 				0x06, 0x00, 0x00, 0x00,
 			},
 		},
+		{
+			name: "gso and gro read only",
+			m: &LinkMessage{
+				Attributes: &LinkAttributes{
+					GSOMaxSize:     &gsoMaxSize,
+					GROIPv4MaxSize: &groIPv4MaxSize,
+				},
+			},
+			b: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			name: "multiple attributes",
+			m: &LinkMessage{
+				Index: 2,
+				Attributes: &LinkAttributes{
+					MTU:        1400,
+					TxQueueLen: &txQueueLen,
+				},
+			},
+			b: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x08, 0x00, 0x04, 0x00, 0x78, 0x05, 0x00, 0x00,
+				0x08, 0x00, 0x0d, 0x00, 0xf4, 0x01, 0x00, 0x00,
+			},
+		},
+		{
+			name: "IPv6 address generation mode",
+			m: &LinkMessage{
+				Index: 2,
+				Attributes: &LinkAttributes{
+					AddrGenMode: &addrGenMode,
+				},
+			},
+			b: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				// IFLA_AF_SPEC
+				0x10, 0x00, 0x1a, 0x80,
+				// AF_INET6
+				0x0c, 0x00, 0x0a, 0x80,
+				// IFLA_INET6_ADDR_GEN_MODE: IN6_ADDR_GEN_MODE_NONE
+				0x05, 0x00, 0x08, 0x00, 0x01, 0x00, 0x00, 0x00,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -161,6 +218,186 @@ func TestLinkMessageMarshalBinary(t *testing.T) {
 	}
 }
 
+func TestLinkServiceSetGSO(t *testing.T) {
+	skipBigEndian(t)
+
+	var (
+		gsoMaxSize     uint32 = 65536
+		groIPv4MaxSize uint32 = 131072
+	)
+
+	c, tc := testConn(t)
+	if err := c.Link.SetGSO(2, &LinkGSO{
+		GSOMaxSize:     &gsoMaxSize,
+		GROIPv4MaxSize: &groIPv4MaxSize,
+	}); err != nil {
+		t.Fatalf("failed to set GSO limits: %v", err)
+	}
+
+	if want, got := netlink.HeaderType(unix.RTM_NEWLINK), tc.send.Header.Type; want != got {
+		t.Fatalf("unexpected request type, want: %v, got: %v", want, got)
+	}
+	if want, got := netlink.Request|netlink.Acknowledge, tc.send.Header.Flags; want != got {
+		t.Fatalf("unexpected request flags, want: %v, got: %v", want, got)
+	}
+
+	want := []byte{
+		0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		// IFLA_GSO_MAX_SIZE
+		0x08, 0x00, 0x29, 0x00, 0x00, 0x00, 0x01, 0x00,
+		// IFLA_GRO_IPV4_MAX_SIZE
+		0x08, 0x00, 0x40, 0x00, 0x00, 0x00, 0x02, 0x00,
+	}
+	if got := tc.send.Data; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected request data:\n- want: [%# x]\n-  got: [%# x]", want, got)
+	}
+
+	if err := c.Link.SetGSO(2, nil); err == nil {
+		t.Fatal("expected an error for nil GSO limits, but none occurred")
+	}
+}
+
+func TestLinkServiceEnslaveCapabilities(t *testing.T) {
+	skipBigEndian(t)
+
+	for _, d := range []LinkDriver{
+		&testCapsDriver{kind: "noslave", master: true},
+		&testCapsDriver{kind: "nomaster", slave: true},
+	} {
+		if err := RegisterDriver(d); err != nil {
+			t.Fatalf("failed to register driver: %v", err)
+		}
+		defer delete(registeredDrivers, d.Kind())
+	}
+
+	tests := []struct {
+		name         string
+		slave        string
+		master       string
+		ok           bool
+		wantRequests int
+	}{
+		{
+			name:   "non-enslaveable link",
+			slave:  "noslave",
+			master: "bridge",
+		},
+		{
+			name:   "master without slave support",
+			slave:  "dummy",
+			master: "nomaster",
+		},
+		{
+			name:         "OK",
+			slave:        "nomaster",
+			master:       "noslave",
+			ok:           true,
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &testLinkConn{
+				links: map[uint32]string{
+					1: tt.slave,
+					2: tt.master,
+				},
+			}
+
+			err := newConn(c).Link.Enslave(1, 2, nil)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to enslave link: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+
+			if want, got := tt.wantRequests, len(c.set); want != got {
+				t.Fatalf("unexpected number of set requests, want: %d, got: %d", want, got)
+			}
+		})
+	}
+}
+
+func TestLinkServiceEnslaveRelease(t *testing.T) {
+	skipBigEndian(t)
+
+	d := &testCapsDriver{kind: "noslave", master: true}
+	if err := RegisterDriver(d); err != nil {
+		t.Fatalf("failed to register driver: %v", err)
+	}
+	defer delete(registeredDrivers, d.Kind())
+
+	c := &testLinkConn{
+		links: map[uint32]string{1: "noslave"},
+	}
+	l := newConn(c).Link
+
+	if err := l.Enslave(1, 0, nil); err != nil {
+		t.Fatalf("failed to release link: %v", err)
+	}
+	if want, got := 0, c.gets; want != got {
+		t.Fatalf("unexpected number of get requests, want: %d, got: %d", want, got)
+	}
+	if want, got := 1, len(c.set); want != got {
+		t.Fatalf("unexpected number of set requests, want: %d, got: %d", want, got)
+	}
+
+	var req LinkMessage
+	if err := req.UnmarshalBinary(c.set[0].Data); err != nil {
+		t.Fatalf("failed to unmarshal request: %v", err)
+	}
+	if req.Attributes.Master == nil || *req.Attributes.Master != 0 {
+		t.Fatalf("unexpected master in release request: %v", req.Attributes.Master)
+	}
+
+	if err := l.Enslave(1, 0, &testSlaveDriver{}); err == nil {
+		t.Fatal("expected an error for slave data without a master, but none occurred")
+	}
+}
+
+func TestLinkDataMarshalBinary(t *testing.T) {
+	skipBigEndian(t)
+
+	// A kind without a registered driver is created from its raw
+	// IFLA_INFO_DATA, here a vxlan with IFLA_VXLAN_ID 100.
+	m := &LinkMessage{
+		Attributes: &LinkAttributes{
+			Info: &LinkInfo{
+				Kind: "vxlan",
+				Data: &LinkData{
+					Name: "vxlan",
+					Data: []byte{0x08, 0x00, 0x01, 0x00, 0x64, 0x00, 0x00, 0x00},
+				},
+			},
+		},
+	}
+
+	want := []byte{
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		// IFLA_LINKINFO
+		0x1c, 0x00, 0x12, 0x00,
+		// IFLA_INFO_KIND
+		0x0a, 0x00, 0x01, 0x00, 0x76, 0x78, 0x6c, 0x61,
+		0x6e, 0x00, 0x00, 0x00,
+		// IFLA_INFO_DATA
+		0x0c, 0x00, 0x02, 0x00, 0x08, 0x00, 0x01, 0x00,
+		0x64, 0x00, 0x00, 0x00,
+	}
+
+	got, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	if !bytes.Equal(want, got) {
+		t.Fatalf("unexpected Message bytes:\n- want: [%# x]\n-  got: [%# x]", want, got)
+	}
+}
+
 func TestLinkMessageUnmarshalBinary(t *testing.T) {
 	skipBigEndian(t)
 
@@ -366,6 +603,105 @@ func TestLinkMessageUnmarshalBinary(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "inet4 devconf",
+			b: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				// IFLA_AF_SPEC
+				0x2c, 0x00, 0x1a, 0x00,
+				// AF_INET
+				0x28, 0x00, 0x02, 0x00,
+				// IFLA_INET_CONF, truncated as sent by older kernels
+				0x24, 0x00, 0x01, 0x00,
+				0x01, 0x00, 0x00, 0x00, // forwarding
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+				0x01, 0x00, 0x00, 0x00, // accept_redirects
+				0x01, 0x00, 0x00, 0x00, // secure_redirects
+				0x01, 0x00, 0x00, 0x00, // send_redirects
+				0x01, 0x00, 0x00, 0x00, // shared_media
+				0x02, 0x00, 0x00, 0x00, // rp_filter
+			},
+			m: &LinkMessage{
+				Attributes: &LinkAttributes{
+					Inet4: &LinkInet4{
+						Forwarding:      1,
+						AcceptRedirects: 1,
+						SecureRedirects: 1,
+						SendRedirects:   1,
+						SharedMedia:     1,
+						RPFilter:        2,
+					},
+				},
+			},
+		},
+		{
+			name: "inet6 devconf",
+			b: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				// IFLA_AF_SPEC
+				0x40, 0x00, 0x1a, 0x00,
+				// AF_INET6
+				0x3c, 0x00, 0x0a, 0x00,
+				// IFLA_INET6_FLAGS: IF_READY
+				0x08, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x80,
+				// IFLA_INET6_CONF, truncated as sent by older kernels
+				0x14, 0x00, 0x02, 0x00,
+				0x00, 0x00, 0x00, 0x00, // forwarding
+				0x40, 0x00, 0x00, 0x00, // hop_limit
+				0xdc, 0x05, 0x00, 0x00, // mtu
+				0x01, 0x00, 0x00, 0x00, // accept_ra
+				// IFLA_INET6_TOKEN
+				0x14, 0x00, 0x07, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+				// IFLA_INET6_ADDR_GEN_MODE: IN6_ADDR_GEN_MODE_STABLE_PRIVACY
+				0x05, 0x00, 0x08, 0x00, 0x02, 0x00, 0x00, 0x00,
+			},
+			m: &LinkMessage{
+				Attributes: &LinkAttributes{
+					Inet6: &LinkInet6{
+						Flags:       0x80000000,
+						Token:       net.ParseIP("::1"),
+						AddrGenMode: IN6AddrGenModeStablePrivacy,
+						Conf: &LinkInet6Conf{
+							HopLimit: 64,
+							MTU:      1500,
+							AcceptRA: 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "altnames",
+			b: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				// IFLA_IFNAME
+				0x09, 0x00, 0x03, 0x00, 0x65, 0x74, 0x68, 0x30,
+				0x00, 0x00, 0x00, 0x00,
+				// IFLA_MTU
+				0x08, 0x00, 0x04, 0x00, 0xdc, 0x05, 0x00, 0x00,
+				// IFLA_PROP_LIST, nested
+				0x20, 0x00, 0x34, 0x80,
+				// IFLA_ALT_IFNAME
+				0x0d, 0x00, 0x35, 0x00, 0x61, 0x6c, 0x74, 0x6e,
+				0x61, 0x6d, 0x65, 0x30, 0x00, 0x00, 0x00, 0x00,
+				// IFLA_ALT_IFNAME
+				0x09, 0x00, 0x35, 0x00, 0x61, 0x6c, 0x74, 0x31,
+				0x00, 0x00, 0x00, 0x00,
+			},
+			m: &LinkMessage{
+				Attributes: &LinkAttributes{
+					Name:     "eth0",
+					MTU:      1500,
+					AltNames: []string{"altname0", "alt1"},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -387,6 +723,143 @@ func TestLinkMessageUnmarshalBinary(t *testing.T) {
 	}
 }
 
+func TestLinkMessageUnmarshalBinaryNestedErrors(t *testing.T) {
+	skipBigEndian(t)
+
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{
+			name: "bad IFLA_PROP_LIST",
+			b: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x08, 0x00, 0x34, 0x80, 0xff, 0x00, 0x35, 0x00,
+			},
+		},
+		{
+			name: "bad IFLA_LINKINFO",
+			b: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x08, 0x00, 0x12, 0x80, 0xff, 0x00, 0x01, 0x00,
+			},
+		},
+		{
+			name: "bad IFLA_AF_SPEC",
+			b: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x08, 0x00, 0x1a, 0x80, 0xff, 0x00, 0x02, 0x00,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &LinkMessage{}
+			if err := m.UnmarshalBinary(tt.b); err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
+
+func TestLinkServiceListBridgeNestedErrors(t *testing.T) {
+	skipBigEndian(t)
+
+	// The IFLA_AF_SPEC block of the AF_BRIDGE message is the one that fails
+	// to decode in the "bad IFLA_AF_SPEC" case above. It holds bridge
+	// attributes that are not decoded by LinkMessage, so it must not fail
+	// the message or the dump.
+	c, tc := testConn(t)
+	tc.receive = []netlink.Message{
+		{
+			Header: netlink.Header{Type: unix.RTM_NEWLINK},
+			Data: mustMarshal(&LinkMessage{
+				Family:     unix.AF_UNSPEC,
+				Index:      1,
+				Attributes: &LinkAttributes{Name: "lo"},
+			}),
+		},
+		{
+			Header: netlink.Header{Type: unix.RTM_NEWLINK},
+			Data: []byte{
+				0x07, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				// IFLA_IFNAME
+				0x0a, 0x00, 0x03, 0x00, 0x76, 0x65, 0x74, 0x68,
+				0x30, 0x00, 0x00, 0x00,
+				// IFLA_AF_SPEC
+				0x08, 0x00, 0x1a, 0x80, 0xff, 0x00, 0x02, 0x00,
+			},
+		},
+	}
+
+	links, err := c.Link.List()
+	if err != nil {
+		t.Fatalf("failed to list links: %v", err)
+	}
+
+	var names []string
+	for _, l := range links {
+		names = append(names, l.Attributes.Name)
+	}
+	if want, got := []string{"lo", "veth0"}, names; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected link names, want: %v, got: %v", want, got)
+	}
+}
+
+func TestLinkMessageUnmarshalBinaryBridge(t *testing.T) {
+	skipBigEndian(t)
+
+	// An AF_BRIDGE link message reuses the IFLA_AF_SPEC nested types for
+	// the bridge attributes, here IFLA_BRIDGE_FLAGS and IFLA_BRIDGE_VLAN_INFO.
+	b := []byte{
+		0x07, 0x00, 0x01, 0x00, 0x02, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		// IFLA_IFNAME
+		0x0a, 0x00, 0x03, 0x00, 0x76, 0x65, 0x74, 0x68,
+		0x30, 0x00, 0x00, 0x00,
+		// IFLA_MASTER
+		0x08, 0x00, 0x0a, 0x00, 0x03, 0x00, 0x00, 0x00,
+		// IFLA_AF_SPEC
+		0x14, 0x00, 0x1a, 0x00,
+		// IFLA_BRIDGE_FLAGS
+		0x06, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+		// IFLA_BRIDGE_VLAN_INFO
+		0x08, 0x00, 0x02, 0x00, 0x06, 0x00, 0x01, 0x00,
+	}
+
+	var m LinkMessage
+	if err := m.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal AF_BRIDGE link message: %v", err)
+	}
+
+	master := uint32(3)
+	want := LinkMessage{
+		Family: unix.AF_BRIDGE,
+		Type:   unix.ARPHRD_ETHER,
+		Index:  2,
+		Attributes: &LinkAttributes{
+			Name:   "veth0",
+			Master: &master,
+		},
+	}
+	if !reflect.DeepEqual(want, m) {
+		t.Fatalf("unexpected link message:\n- want: %#v\n-  got: %#v", want, m)
+	}
+
+	var vm BridgeVlanMessage
+	if err := vm.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal bridge vlan message: %v", err)
+	}
+	if want, got := []BridgeVlanInfo{{Flags: BridgeVlanFlagPVID | BridgeVlanFlagUntagged, VID: 1}}, vm.Vlans; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected VLANs, want: %v, got: %v", want, got)
+	}
+}
+
 func TestLinkStatsUnmarshalBinary(t *testing.T) {
 	skipBigEndian(t)
 
@@ -520,6 +993,53 @@ func TestLinkStatsUnmarshalBinary(t *testing.T) {
 	}
 }
 
+func TestLinkStatsUnmarshalBinaryOffsets(t *testing.T) {
+	skipBigEndian(t)
+
+	// Number each counter of struct rtnl_link_stats after its position, so
+	// that a field decoded from the wrong offset stands out.
+	b := make([]byte, 96)
+	for i := 0; i < len(b)/4; i++ {
+		nativeEndian.PutUint32(b[i*4:], uint32(i+1))
+	}
+
+	want := &LinkStats{
+		RXPackets:         1,
+		TXPackets:         2,
+		RXBytes:           3,
+		TXBytes:           4,
+		RXErrors:          5,
+		TXErrors:          6,
+		RXDropped:         7,
+		TXDropped:         8,
+		Multicast:         9,
+		Collisions:        10,
+		RXLengthErrors:    11,
+		RXOverErrors:      12,
+		RXCRCErrors:       13,
+		RXFrameErrors:     14,
+		RXFIFOErrors:      15,
+		RXMissedErrors:    16,
+		TXAbortedErrors:   17,
+		TXCarrierErrors:   18,
+		TXFIFOErrors:      19,
+		TXHeartbeatErrors: 20,
+		TXWindowErrors:    21,
+		RXCompressed:      22,
+		TXCompressed:      23,
+		RXNoHandler:       24,
+	}
+
+	got := &LinkStats{}
+	if err := got.unmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected LinkStats:\n- want: %#v\n-  got: %#v", want, got)
+	}
+}
+
 func TestLinkStats64UnmarshalBinary(t *testing.T) {
 	skipBigEndian(t)
 
@@ -737,3 +1257,430 @@ func TestLinkStats64UnmarshalBinary(t *testing.T) {
 		})
 	}
 }
+
+func TestLinkAttributesStatistics(t *testing.T) {
+	skipBigEndian(t)
+
+	stats := make([]byte, 96)
+	nativeEndian.PutUint32(stats[0:4], 1)   // RXPackets
+	nativeEndian.PutUint32(stats[92:96], 2) // RXNoHandler
+	stats64 := make([]byte, 200)
+	nativeEndian.PutUint64(stats64[0:8], 1<<40) // RXPackets
+
+	tests := []struct {
+		name    string
+		stats   bool
+		stats64 bool
+		want    *LinkStats64
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:  "32 bits only",
+			stats: true,
+			want:  &LinkStats64{RXPackets: 1, RXNoHandler: 2},
+		},
+		{
+			name:    "64 bits only",
+			stats64: true,
+			want:    &LinkStats64{RXPackets: 1 << 40},
+		},
+		{
+			name:    "both",
+			stats:   true,
+			stats64: true,
+			want:    &LinkStats64{RXPackets: 1 << 40},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ae := netlink.NewAttributeEncoder()
+			ae.String(unix.IFLA_IFNAME, "eth0")
+			if tt.stats {
+				ae.Bytes(unix.IFLA_STATS, stats)
+			}
+			if tt.stats64 {
+				ae.Bytes(unix.IFLA_STATS64, stats64)
+			}
+			attrs, err := ae.Encode()
+			if err != nil {
+				t.Fatalf("failed to encode attributes: %v", err)
+			}
+
+			m := &LinkMessage{}
+			if err := m.UnmarshalBinary(append(make([]byte, unix.SizeofIfInfomsg), attrs...)); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			if want, got := tt.want, m.Attributes.Statistics(); !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected statistics:\n- want: %+v\n-  got: %+v", want, got)
+			}
+		})
+	}
+}
+
+func TestLinkMessageValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *LinkMessage
+		ok   bool
+	}{
+		{
+			name: "name too long",
+			m: &LinkMessage{
+				Attributes: &LinkAttributes{
+					Name: "abcdefghijklmnop",
+				},
+			},
+		},
+		{
+			name: "OK",
+			m: &LinkMessage{
+				Attributes: &LinkAttributes{
+					Name: "abcdefghijklmno",
+				},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.m.Validate()
+			if tt.ok && err != nil {
+				t.Fatalf("failed to validate message: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
+
+func TestLinkMessageIsOperational(t *testing.T) {
+	var (
+		carrierUp   uint8 = 1
+		carrierDown uint8
+	)
+
+	tests := []struct {
+		name string
+		m    *LinkMessage
+		ok   bool
+	}{
+		{
+			name: "admin down",
+			m: &LinkMessage{
+				Attributes: &LinkAttributes{
+					Carrier:          &carrierUp,
+					OperationalState: OperStateUp,
+				},
+			},
+		},
+		{
+			name: "up without attributes",
+			m: &LinkMessage{
+				Flags: unix.IFF_UP,
+			},
+			ok: true,
+		},
+		{
+			name: "up without carrier",
+			m: &LinkMessage{
+				Flags: unix.IFF_UP,
+				Attributes: &LinkAttributes{
+					Carrier:          &carrierDown,
+					OperationalState: OperStateUp,
+				},
+			},
+		},
+		{
+			name: "up with carrier, operstate up",
+			m: &LinkMessage{
+				Flags: unix.IFF_UP,
+				Attributes: &LinkAttributes{
+					Carrier:          &carrierUp,
+					OperationalState: OperStateUp,
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "up with carrier, operstate unknown",
+			m: &LinkMessage{
+				Flags: unix.IFF_UP | unix.IFF_LOOPBACK,
+				Attributes: &LinkAttributes{
+					Carrier:          &carrierUp,
+					OperationalState: OperStateUnknown,
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "up with carrier, operstate dormant",
+			m: &LinkMessage{
+				Flags: unix.IFF_UP,
+				Attributes: &LinkAttributes{
+					Carrier:          &carrierUp,
+					OperationalState: OperStateDormant,
+				},
+			},
+		},
+		{
+			name: "up without carrier attribute, operstate lower layer down",
+			m: &LinkMessage{
+				Flags: unix.IFF_UP,
+				Attributes: &LinkAttributes{
+					OperationalState: OperStateLowerLayerDown,
+				},
+			},
+		},
+		{
+			name: "up without carrier attribute, operstate up",
+			m: &LinkMessage{
+				Flags: unix.IFF_UP,
+				Attributes: &LinkAttributes{
+					OperationalState: OperStateUp,
+				},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.IsOperational(); got != tt.ok {
+				t.Fatalf("unexpected IsOperational result, want: %v, got: %v", tt.ok, got)
+			}
+		})
+	}
+}
+
+func TestCarrierEvents(t *testing.T) {
+	var (
+		up   uint8 = 1
+		down uint8
+	)
+
+	link := func(index uint32, carrier *uint8) Message {
+		return &LinkMessage{
+			Index:      index,
+			Attributes: &LinkAttributes{Carrier: carrier},
+		}
+	}
+
+	msgs := make(chan Message)
+	go func() {
+		defer close(msgs)
+		for _, m := range []Message{
+			link(1, &down),
+			link(2, &up),
+			// No change in carrier.
+			link(1, &down),
+			link(1, &up),
+			// Ignored messages.
+			link(2, nil),
+			&LinkMessage{Index: 2},
+			&AddressMessage{Index: 2},
+			link(2, &down),
+			link(1, &up),
+		} {
+			msgs <- m
+		}
+	}()
+
+	var got []CarrierEvent
+	for e := range CarrierEvents(msgs) {
+		got = append(got, e)
+	}
+
+	want := []CarrierEvent{
+		{Index: 1, Up: true},
+		{Index: 2, Up: false},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected carrier events:\n want: %+v\n  got: %+v", want, got)
+	}
+}
+
+func TestLinkBriefUnmarshalBinary(t *testing.T) {
+	b, err := (&LinkMessage{
+		Index: 2,
+		Flags: unix.IFF_UP,
+		Attributes: &LinkAttributes{
+			Address:   []byte{0x02, 0x00, 0x5e, 0x10, 0x00, 0x01},
+			Broadcast: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			MTU:       1500,
+			Name:      "eth0",
+			QueueDisc: "fq_codel",
+		},
+	}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal link message: %v", err)
+	}
+
+	var got LinkBrief
+	if err := got.unmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal link brief: %v", err)
+	}
+
+	want := LinkBrief{
+		Index:        2,
+		Name:         "eth0",
+		MTU:          1500,
+		HardwareAddr: []byte{0x02, 0x00, 0x5e, 0x10, 0x00, 0x01},
+		Flags:        unix.IFF_UP,
+		QueueDisc:    "fq_codel",
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected link brief:\n want: %+v\n  got: %+v", want, got)
+	}
+
+	var m LinkMessage
+	if err := m.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal link message: %v", err)
+	}
+	if m.Attributes.QueueDisc != got.QueueDisc {
+		t.Fatalf("unexpected queueing discipline: want %q, got %q", got.QueueDisc, m.Attributes.QueueDisc)
+	}
+
+	if err := got.unmarshalBinary(b[:unix.SizeofIfInfomsg-1]); err == nil {
+		t.Fatal("expected an error for a short message, but none occurred")
+	}
+}
+
+func TestLinkMessageUnmarshalBinaryUnknownAttrs(t *testing.T) {
+	skipBigEndian(t)
+
+	b := []byte{
+		0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		// IFLA_MTU
+		0x08, 0x00, 0x04, 0x00, 0xdc, 0x05, 0x00, 0x00,
+		// Unrecognized attribute 0x3ff0
+		0x08, 0x00, 0xf0, 0x3f, 0x01, 0x00, 0x00, 0x00,
+		// IFLA_IFNAME
+		0x07, 0x00, 0x03, 0x00, 0x6c, 0x6f, 0x00, 0x00,
+		// IFLA_PROMISCUITY, known but not decoded
+		0x08, 0x00, 0x1e, 0x00, 0x00, 0x00, 0x00, 0x00,
+		// Unrecognized attribute 0x3ff1
+		0x05, 0x00, 0xf1, 0x3f, 0x01, 0x00, 0x00, 0x00,
+	}
+
+	tests := []struct {
+		name   string
+		record bool
+		want   []uint16
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:   "enabled",
+			record: true,
+			want:   []uint16{0x3ff0, 0x3ff1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RecordUnknownAttrs(tt.record)
+			defer RecordUnknownAttrs(false)
+
+			var m LinkMessage
+			if err := m.UnmarshalBinary(b); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			if m.Attributes.MTU != 1500 || m.Attributes.Name != "lo" {
+				t.Fatalf("unexpected known attributes: %+v", m.Attributes)
+			}
+			if want, got := tt.want, m.Attributes.UnknownAttrs; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected unknown attributes: want %#x, got %#x", want, got)
+			}
+		})
+	}
+}
+
+func TestIN6AddrGenModeString(t *testing.T) {
+	tests := []struct {
+		m    IN6AddrGenMode
+		name string
+	}{
+		{m: IN6AddrGenModeEUI64, name: "eui64"},
+		{m: IN6AddrGenModeNone, name: "none"},
+		{m: IN6AddrGenModeStablePrivacy, name: "stable_secret"},
+		{m: IN6AddrGenModeRandom, name: "random"},
+		{m: 4, name: "unknown IN6AddrGenMode value (4)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.name {
+			t.Fatalf("unexpected string for %d, want: %q, got: %q", tt.m, tt.name, got)
+		}
+	}
+}
+
+// testLinkConn answers link requests for a fixed set of link kinds indexed
+// by interface index, and records all other link requests.
+type testLinkConn struct {
+	links map[uint32]string
+	gets  int
+	set   []netlink.Message
+
+	noopConn
+}
+
+func (c *testLinkConn) Execute(m netlink.Message) ([]netlink.Message, error) {
+	if m.Header.Type != unix.RTM_GETLINK {
+		c.set = append(c.set, m)
+		return nil, nil
+	}
+	c.gets++
+
+	var req LinkMessage
+	if err := req.UnmarshalBinary(m.Data); err != nil {
+		return nil, err
+	}
+
+	kind, ok := c.links[req.Index]
+	if !ok {
+		return nil, unix.ENODEV
+	}
+
+	return []netlink.Message{{
+		Header: netlink.Header{Type: unix.RTM_NEWLINK},
+		Data: mustMarshal(&LinkMessage{
+			Index: req.Index,
+			Attributes: &LinkAttributes{
+				Info: &LinkInfo{Kind: kind},
+			},
+		}),
+	}}, nil
+}
+
+type testCapsDriver struct {
+	kind          string
+	slave, master bool
+}
+
+var _ LinkDriverCapabilities = &testCapsDriver{}
+
+func (d *testCapsDriver) New() LinkDriver                          { return &testCapsDriver{kind: d.kind} }
+func (d *testCapsDriver) Decode(_ *netlink.AttributeDecoder) error { return nil }
+func (d *testCapsDriver) Encode(_ *netlink.AttributeEncoder) error { return nil }
+func (d *testCapsDriver) Kind() string                             { return d.kind }
+func (d *testCapsDriver) CanBeSlave() bool                         { return d.slave }
+func (d *testCapsDriver) CanHaveSlaves() bool                      { return d.master }
+
+type testSlaveDriver struct{}
+
+var _ LinkSlaveDriver = &testSlaveDriver{}
+
+func (d *testSlaveDriver) New() LinkDriver                          { return &testSlaveDriver{} }
+func (d *testSlaveDriver) Decode(_ *netlink.AttributeDecoder) error { return nil }
+func (d *testSlaveDriver) Encode(_ *netlink.AttributeEncoder) error { return nil }
+func (d *testSlaveDriver) Kind() string                             { return "test" }
+func (d *testSlaveDriver) Slave()                                   {}
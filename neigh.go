@@ -1,6 +1,7 @@
 package rtnetlink
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"net"
@@ -13,6 +14,9 @@ import (
 var (
 	// errInvalidNeighMessage is returned when a LinkMessage is malformed.
 	errInvalidNeighMessage = errors.New("rtnetlink NeighMessage is invalid or too short")
+
+	// errInvalidNeighMessageAttr is returned when neigh attributes are malformed.
+	errInvalidNeighMessageAttr = errors.New("rtnetlink NeighMessage has a wrong attribute data length")
 )
 
 var _ Message = &NeighMessage{}
@@ -52,7 +56,7 @@ func (m *NeighMessage) MarshalBinary() ([]byte, error) {
 	if m.Attributes != nil {
 		ae := netlink.NewAttributeEncoder()
 		ae.ByteOrder = nativeEndian
-		err := m.Attributes.encode(ae)
+		err := m.Attributes.encode(ae, m.Family)
 		if err != nil {
 			return nil, err
 		}
@@ -99,13 +103,33 @@ func (m *NeighMessage) UnmarshalBinary(b []byte) error {
 // rtMessage is an empty method to sattisfy the Message interface.
 func (*NeighMessage) rtMessage() {}
 
+// Validate verifies that the NeighMessage refers to an interface and that its
+// neighbor address matches the address family.
+func (m *NeighMessage) Validate() error {
+	if m.Index == 0 {
+		return errors.New("rtnetlink: NeighMessage requires an interface index")
+	}
+
+	if m.Attributes == nil {
+		return nil
+	}
+
+	return validateIP(uint8(m.Family), m.Attributes.Address)
+}
+
 // NeighService is used to retrieve rtnetlink family information.
 type NeighService struct {
 	c *Conn
 }
 
-// New creates a new interface using the LinkMessage information.
+// New creates a new neighbor entry using the NeighMessage information. The
+// message is sent as given, so its State, Flags, Type and attributes such as
+// LLAddress and IfIndex are all honored.
 func (l *NeighService) New(req *NeighMessage) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
 	flags := netlink.Request | netlink.Create | netlink.Acknowledge | netlink.Excl
 	_, err := l.c.Execute(req, unix.RTM_NEWNEIGH, flags)
 	if err != nil {
@@ -115,9 +139,12 @@ func (l *NeighService) New(req *NeighMessage) error {
 	return nil
 }
 
-// Delete removes an neighbor entry by index.
-func (l *NeighService) Delete(index uint32) error {
-	req := &NeighMessage{}
+// Delete removes the neighbor entry described by the NeighMessage, which
+// is identified by its interface index and neighbor Address.
+func (l *NeighService) Delete(req *NeighMessage) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
 
 	flags := netlink.Request | netlink.Acknowledge
 	_, err := l.c.Execute(req, unix.RTM_DELNEIGH, flags)
@@ -128,6 +155,67 @@ func (l *NeighService) Delete(index uint32) error {
 	return nil
 }
 
+// FDBTarget selects the forwarding database a bridge FDB entry is programmed
+// into.
+type FDBTarget uint8
+
+const (
+	// FDBTargetSelf targets the FDB of the device itself, e.g. of a vxlan
+	// device (NTF_SELF).
+	FDBTargetSelf FDBTarget = iota
+
+	// FDBTargetMaster targets the FDB of the bridge the device is enslaved
+	// to (NTF_MASTER).
+	FDBTargetMaster
+)
+
+// fdbMessage returns a copy of req with the NTF_SELF or NTF_MASTER flag set
+// according to target.
+func fdbMessage(req *NeighMessage, target FDBTarget) (*NeighMessage, error) {
+	if req.Family != unix.AF_BRIDGE {
+		return nil, fmt.Errorf("rtnetlink: FDB entries require family AF_BRIDGE, got %d", req.Family)
+	}
+
+	m := *req
+	m.Flags &^= unix.NTF_SELF | unix.NTF_MASTER
+	switch target {
+	case FDBTargetSelf:
+		m.Flags |= unix.NTF_SELF
+	case FDBTargetMaster:
+		m.Flags |= unix.NTF_MASTER
+	default:
+		return nil, fmt.Errorf("rtnetlink: unknown FDBTarget value (%d)", target)
+	}
+
+	return &m, nil
+}
+
+// AddFDB creates a bridge FDB entry in the forwarding database selected by
+// target. The NeighMessage must use the AF_BRIDGE family. Entries of a vxlan
+// device point at a remote VTEP using the Address, VNI and Port attributes.
+func (l *NeighService) AddFDB(req *NeighMessage, target FDBTarget) error {
+	m, err := fdbMessage(req, target)
+	if err != nil {
+		return err
+	}
+
+	return l.New(m)
+}
+
+// DeleteFDB removes a bridge FDB entry from the forwarding database selected
+// by target. The NeighMessage must use the AF_BRIDGE family.
+func (l *NeighService) DeleteFDB(req *NeighMessage, target FDBTarget) error {
+	m, err := fdbMessage(req, target)
+	if err != nil {
+		return err
+	}
+
+	flags := netlink.Request | netlink.Acknowledge
+	_, err = l.c.Execute(m, unix.RTM_DELNEIGH, flags)
+
+	return err
+}
+
 // List retrieves all neighbors.
 func (l *NeighService) List() ([]NeighMessage, error) {
 	req := NeighMessage{}
@@ -174,6 +262,13 @@ type NeighAttributes struct {
 	LLAddress net.HardwareAddr // a neighbor cache link layer address
 	CacheInfo *NeighCacheInfo  // cache statistics
 	IfIndex   uint32
+
+	// The following attributes are used by bridge FDB entries (AF_BRIDGE).
+	Vlan   *uint16 // VLAN of the entry
+	VNI    *uint32 // VXLAN network identifier of the remote
+	Port   *uint16 // UDP destination port of the remote
+	Master *uint32 // interface index of the bridge the entry belongs to
+	SrcVNI *uint32 // source VXLAN network identifier, for collect metadata devices
 }
 
 func (a *NeighAttributes) decode(ad *netlink.AttributeDecoder) error {
@@ -200,17 +295,60 @@ func (a *NeighAttributes) decode(ad *netlink.AttributeDecoder) error {
 			}
 		case unix.NDA_IFINDEX:
 			a.IfIndex = ad.Uint32()
+		case unix.NDA_VLAN:
+			v := ad.Uint16()
+			a.Vlan = &v
+		case unix.NDA_PORT:
+			b := ad.Bytes()
+			if len(b) != 2 {
+				return errInvalidNeighMessageAttr
+			}
+			v := binary.BigEndian.Uint16(b)
+			a.Port = &v
+		case unix.NDA_VNI:
+			v := ad.Uint32()
+			a.VNI = &v
+		case unix.NDA_MASTER:
+			v := ad.Uint32()
+			a.Master = &v
+		case unix.NDA_SRC_VNI:
+			v := ad.Uint32()
+			a.SrcVNI = &v
 		}
 	}
 
-	return nil
+	return ad.Err()
 }
 
-func (a *NeighAttributes) encode(ae *netlink.AttributeEncoder) error {
+func (a *NeighAttributes) encode(ae *netlink.AttributeEncoder, family uint16) error {
 	ae.Uint16(unix.NDA_UNSPEC, 0)
-	ae.Bytes(unix.NDA_DST, a.Address)
+
+	// For bridge FDB entries the destination and interface index refer to
+	// an optional remote (e.g. a vxlan VTEP) and are rejected when empty.
+	if family != unix.AF_BRIDGE || a.Address != nil {
+		ae.Bytes(unix.NDA_DST, a.Address)
+	}
 	ae.Bytes(unix.NDA_LLADDR, a.LLAddress)
-	ae.Uint32(unix.NDA_IFINDEX, a.IfIndex)
+	if family != unix.AF_BRIDGE || a.IfIndex != 0 {
+		ae.Uint32(unix.NDA_IFINDEX, a.IfIndex)
+	}
+	if a.Vlan != nil {
+		ae.Uint16(unix.NDA_VLAN, *a.Vlan)
+	}
+	if a.Port != nil {
+		b := make([]byte, 2)
+		binary.BigEndian.PutUint16(b, *a.Port)
+		ae.Bytes(unix.NDA_PORT, b)
+	}
+	if a.VNI != nil {
+		ae.Uint32(unix.NDA_VNI, *a.VNI)
+	}
+	if a.Master != nil {
+		ae.Uint32(unix.NDA_MASTER, *a.Master)
+	}
+	if a.SrcVNI != nil {
+		ae.Uint32(unix.NDA_SRC_VNI, *a.SrcVNI)
+	}
 
 	return nil
 }
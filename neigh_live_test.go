@@ -0,0 +1,201 @@
+//go:build integration
+// +build integration
+
+package rtnetlink
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/jsimonetti/rtnetlink/v2/internal/testutils"
+	"github.com/mdlayher/netlink"
+	"golang.org/x/sys/unix"
+)
+
+func TestNeighFDB(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	const (
+		vxlanIndex  = 1300
+		bridgeIndex = 1301
+		vethIndex   = 1302
+		peerIndex   = 1303
+	)
+
+	ae := netlink.NewAttributeEncoder()
+	ae.Uint32(unix.IFLA_VXLAN_ID, 100)
+	vxlanData, err := ae.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode vxlan data: %v", err)
+	}
+
+	for _, l := range []struct {
+		index uint32
+		name  string
+		data  []byte
+	}{
+		{vxlanIndex, "vxlan", vxlanData},
+		{bridgeIndex, "bridge", nil},
+	} {
+		if err := conn.Link.New(&LinkMessage{
+			Index: l.index,
+			Attributes: &LinkAttributes{
+				Info: &LinkInfo{
+					Kind: l.name,
+					Data: &LinkData{Name: l.name, Data: l.data},
+				},
+			},
+		}); err != nil {
+			t.Fatalf("failed to create %s link: %v", l.name, err)
+		}
+		defer conn.Link.Delete(l.index)
+	}
+
+	createVeth(t, conn, vethIndex, peerIndex)
+	defer conn.Link.Delete(vethIndex)
+
+	if err := conn.Link.Enslave(vethIndex, bridgeIndex, nil); err != nil {
+		t.Fatalf("failed to enslave veth to bridge: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		index  uint32
+		target FDBTarget
+		dst    net.IP
+	}{
+		{
+			name:   "self on vxlan",
+			index:  vxlanIndex,
+			target: FDBTargetSelf,
+			dst:    net.IP{192, 0, 2, 1},
+		},
+		{
+			name:   "master on bridge port",
+			index:  vethIndex,
+			target: FDBTargetMaster,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mac := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x13, 0x00}
+			req := &NeighMessage{
+				Family: unix.AF_BRIDGE,
+				Index:  tt.index,
+				State:  unix.NUD_PERMANENT,
+				Attributes: &NeighAttributes{
+					Address:   tt.dst,
+					LLAddress: mac,
+				},
+			}
+
+			if err := conn.Neigh.AddFDB(req, tt.target); err != nil {
+				t.Fatalf("failed to add FDB entry: %v", err)
+			}
+
+			if !hasFDB(t, conn, tt.index, mac) {
+				t.Fatal("FDB entry not found after adding it")
+			}
+
+			if err := conn.Neigh.DeleteFDB(req, tt.target); err != nil {
+				t.Fatalf("failed to delete FDB entry: %v", err)
+			}
+
+			if hasFDB(t, conn, tt.index, mac) {
+				t.Fatal("FDB entry still found after deleting it")
+			}
+		})
+	}
+}
+
+// hasFDB reports whether an FDB entry for mac exists on the interface with
+// index.
+func hasFDB(tb testing.TB, conn *Conn, index uint32, mac net.HardwareAddr) bool {
+	tb.Helper()
+
+	// Bridge FDB entries are only dumped for the AF_BRIDGE family.
+	msgs, err := conn.Execute(&NeighMessage{Family: unix.AF_BRIDGE}, unix.RTM_GETNEIGH, netlink.Request|netlink.Dump)
+	if err != nil {
+		tb.Fatalf("failed to list FDB entries: %v", err)
+	}
+
+	for _, m := range msgs {
+		n := m.(*NeighMessage)
+		if n.Family == unix.AF_BRIDGE && n.Index == index &&
+			n.Attributes != nil && bytes.Equal(n.Attributes.LLAddress, mac) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNeighNewDelete(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	const vethIndex = 1900
+
+	createVeth(t, conn, vethIndex, vethIndex+1)
+	defer conn.Link.Delete(vethIndex)
+
+	var (
+		ip  = net.IPv4(192, 0, 2, 1).To4()
+		mac = net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+	)
+
+	req := &NeighMessage{
+		Family: unix.AF_INET,
+		Index:  vethIndex,
+		State:  unix.NUD_PERMANENT,
+		Attributes: &NeighAttributes{
+			Address:   ip,
+			LLAddress: mac,
+			IfIndex:   vethIndex,
+		},
+	}
+	if err := conn.Neigh.New(req); err != nil {
+		t.Fatalf("failed to add neighbor: %v", err)
+	}
+
+	find := func() *NeighMessage {
+		t.Helper()
+
+		neighs, err := conn.Neigh.List()
+		if err != nil {
+			t.Fatalf("failed to list neighbors: %v", err)
+		}
+		for i, n := range neighs {
+			if n.Index == vethIndex && n.Attributes != nil && n.Attributes.Address.Equal(ip) {
+				return &neighs[i]
+			}
+		}
+		return nil
+	}
+
+	n := find()
+	if n == nil {
+		t.Fatal("neighbor was not added")
+	}
+	if want, got := uint16(unix.NUD_PERMANENT), n.State; want != got {
+		t.Fatalf("unexpected neighbor state, want: %#x, got: %#x", want, got)
+	}
+	if want, got := mac, n.Attributes.LLAddress; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected link layer address, want: %s, got: %s", want, got)
+	}
+
+	if err := conn.Neigh.Delete(req); err != nil {
+		t.Fatalf("failed to delete neighbor: %v", err)
+	}
+	if find() != nil {
+		t.Fatal("neighbor was not deleted")
+	}
+}
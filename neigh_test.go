@@ -195,3 +195,170 @@ func TestNeighMessageUnmarshalBinary(t *testing.T) {
 		})
 	}
 }
+
+func TestNeighMessageValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *NeighMessage
+		ok   bool
+	}{
+		{
+			name: "no interface index",
+			m: &NeighMessage{
+				Family: unix.AF_INET,
+			},
+		},
+		{
+			name: "IPv6 address with IPv4 family",
+			m: &NeighMessage{
+				Family: unix.AF_INET,
+				Index:  1,
+				Attributes: &NeighAttributes{
+					Address: net.ParseIP("2001:db8::1"),
+				},
+			},
+		},
+		{
+			name: "OK",
+			m: &NeighMessage{
+				Family: unix.AF_INET,
+				Index:  1,
+				Attributes: &NeighAttributes{
+					Address:   net.IPv4(192, 0, 2, 1),
+					LLAddress: net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0x00, 0x01},
+				},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.m.Validate()
+			if tt.ok && err != nil {
+				t.Fatalf("failed to validate message: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
+
+func TestNeighFDBMessage(t *testing.T) {
+	skipBigEndian(t)
+
+	req := &NeighMessage{
+		Family: unix.AF_BRIDGE,
+		Index:  2,
+		State:  unix.NUD_PERMANENT,
+		Flags:  unix.NTF_MASTER,
+		Attributes: &NeighAttributes{
+			LLAddress: net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+		},
+	}
+
+	if _, err := fdbMessage(&NeighMessage{Family: unix.AF_INET}, FDBTargetSelf); err == nil {
+		t.Fatal("expected an error for non-AF_BRIDGE family, but none occurred")
+	}
+
+	m, err := fdbMessage(req, FDBTargetSelf)
+	if err != nil {
+		t.Fatalf("failed to create FDB message: %v", err)
+	}
+	if want, got := uint8(unix.NTF_SELF), m.Flags; want != got {
+		t.Fatalf("unexpected flags, want: %#x, got: %#x", want, got)
+	}
+	if req.Flags != unix.NTF_MASTER {
+		t.Fatal("request flags were modified")
+	}
+
+	// The empty destination and interface index are omitted for FDB
+	// entries.
+	want := []byte{
+		0x07, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+		0x80, 0x00, 0x02, 0x00, 0x06, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x0a, 0x00, 0x02, 0x00,
+		0x02, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+	}
+	got, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Fatalf("unexpected Message bytes:\n- want: [%# x]\n-  got: [%# x]", want, got)
+	}
+}
+
+func TestNeighMessageFDBAttributes(t *testing.T) {
+	skipBigEndian(t)
+
+	var (
+		vlan   uint16 = 10
+		port   uint16 = 4789
+		vni    uint32 = 100
+		master uint32 = 3
+		srcVNI uint32 = 200
+	)
+
+	// A VXLAN FDB entry pointing at a remote VTEP.
+	m := &NeighMessage{
+		Family: unix.AF_BRIDGE,
+		Index:  5,
+		State:  unix.NUD_PERMANENT,
+		Flags:  unix.NTF_SELF,
+		Attributes: &NeighAttributes{
+			Address:   net.IPv4(192, 0, 2, 10).To4(),
+			LLAddress: net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+			Vlan:      &vlan,
+			Port:      &port,
+			VNI:       &vni,
+			Master:    &master,
+			SrcVNI:    &srcVNI,
+		},
+	}
+
+	want := []byte{
+		0x07, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00,
+		0x80, 0x00, 0x02, 0x00,
+		// NDA_UNSPEC
+		0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		// NDA_DST
+		0x08, 0x00, 0x01, 0x00, 0xc0, 0x00, 0x02, 0x0a,
+		// NDA_LLADDR
+		0x0a, 0x00, 0x02, 0x00, 0x02, 0x00, 0x00, 0x00,
+		0x00, 0x01, 0x00, 0x00,
+		// NDA_VLAN
+		0x06, 0x00, 0x05, 0x00, 0x0a, 0x00, 0x00, 0x00,
+		// NDA_PORT, in network byte order
+		0x06, 0x00, 0x06, 0x00, 0x12, 0xb5, 0x00, 0x00,
+		// NDA_VNI
+		0x08, 0x00, 0x07, 0x00, 0x64, 0x00, 0x00, 0x00,
+		// NDA_MASTER
+		0x08, 0x00, 0x09, 0x00, 0x03, 0x00, 0x00, 0x00,
+		// NDA_SRC_VNI
+		0x08, 0x00, 0x0b, 0x00, 0xc8, 0x00, 0x00, 0x00,
+	}
+
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if !bytes.Equal(want, b) {
+		t.Fatalf("unexpected bytes:\n- want: [%# x]\n-  got: [%# x]", want, b)
+	}
+
+	var got NeighMessage
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if diff := cmp.Diff(m, &got); diff != "" {
+		t.Fatalf("unexpected neigh message (-want +got):\n%s", diff)
+	}
+
+	// NDA_PORT must hold exactly two bytes.
+	bad := append(want[:unix.SizeofNdMsg:unix.SizeofNdMsg], 0x08, 0x00, 0x06, 0x00, 0x12, 0xb5, 0x00, 0x00)
+	if err := (&NeighMessage{}).UnmarshalBinary(bad); err == nil {
+		t.Fatal("expected an error for a malformed NDA_PORT, but none occurred")
+	}
+}
@@ -1,7 +1,16 @@
 package rtnetlink
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
 	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
 )
 
 // NetNS represents a Linux network namespace handle to specify in
@@ -44,3 +53,287 @@ func (ns *NetNS) value() (uint16, uint32) {
 	}
 	return 0, 0
 }
+
+// NSManager maintains a set of connections keyed by network namespace.
+// Connections are dialed on first use and reused for every later request for
+// the same network namespace, until Close closes all of them.
+//
+// The zero value is ready to use. An NSManager is safe for concurrent use.
+type NSManager struct {
+	mu    sync.Mutex
+	conns map[string]*Conn
+}
+
+// netNSIdentity returns the identity of the network namespace the file
+// descriptor fd refers to, made of the device and inode of its nsfs file. It
+// is a variable so it can be replaced in tests.
+var netNSIdentity = func(fd int) (string, error) {
+	var st unix.Stat_t
+	if err := unix.Fstat(fd, &st); err != nil {
+		return "", fmt.Errorf("rtnetlink: failed to identify network namespace of fd %d: %w", fd, err)
+	}
+	return fmt.Sprintf("%d:%d", st.Dev, st.Ino), nil
+}
+
+// Conn returns the connection to the network namespace ns, dialing it if
+// there is no connection yet. A nil ns refers to the network namespace of the
+// calling process.
+//
+// Connections are keyed by the network namespace itself rather than by the
+// fd or pid of ns, so handles referring to the same network namespace share
+// a connection, and a reused fd number or pid never yields the connection of
+// a network namespace it no longer refers to.
+func (m *NSManager) Conn(ns *NetNS) (*Conn, error) {
+	if ns == nil {
+		return m.conn(-1)
+	}
+
+	switch typ, v := ns.value(); typ {
+	case unix.IFLA_NET_NS_FD:
+		return m.conn(int(v))
+	case unix.IFLA_NET_NS_PID:
+		f, err := os.Open(fmt.Sprintf("/proc/%d/ns/net", v))
+		if err != nil {
+			return nil, fmt.Errorf("rtnetlink: failed to open network namespace of pid %d: %w", v, err)
+		}
+		defer f.Close()
+
+		return m.conn(int(f.Fd()))
+	default:
+		return m.conn(-1)
+	}
+}
+
+// ConnByName returns the connection to the named network namespace, as
+// created by "ip netns add", dialing it if there is no connection yet. It
+// shares connections with Conn for handles to the same network namespace.
+func (m *NSManager) ConnByName(name string) (*Conn, error) {
+	if name == "" || strings.ContainsRune(name, '/') {
+		return nil, fmt.Errorf("rtnetlink: invalid network namespace name %q", name)
+	}
+
+	f, err := os.Open(filepath.Join("/run/netns", name))
+	if err != nil {
+		return nil, fmt.Errorf("rtnetlink: failed to open network namespace %q: %w", name, err)
+	}
+	defer f.Close()
+
+	return m.conn(int(f.Fd()))
+}
+
+// conn returns the connection to the network namespace fd refers to, or to
+// the network namespace of the calling process if fd is -1.
+func (m *NSManager) conn(fd int) (*Conn, error) {
+	var id string
+	if fd >= 0 {
+		var err error
+		if id, err = netNSIdentity(fd); err != nil {
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.conns[id]; ok {
+		return c, nil
+	}
+
+	var cfg *netlink.Config
+	if fd >= 0 {
+		cfg = &netlink.Config{NetNS: fd}
+	}
+
+	c, err := Dial(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.conns == nil {
+		m.conns = make(map[string]*Conn)
+	}
+	m.conns[id] = c
+
+	return c, nil
+}
+
+// Close closes all connections of the NSManager. The NSManager can be reused
+// afterwards, in which case new connections are dialed. The first error
+// encountered while closing is returned.
+func (m *NSManager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var err error
+	for id, c := range m.conns {
+		if cerr := c.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+		delete(m.conns, id)
+	}
+
+	return err
+}
+
+// ErrNetNSIDUnresolvable is returned by ResolveNetNSID when no network
+// namespace with the requested netnsid can be found.
+var ErrNetNSIDUnresolvable = errors.New("rtnetlink: netnsid cannot be resolved to a network namespace")
+
+// sizeofNetNSIDMessage is the size of struct rtgenmsg aligned to 4 bytes.
+const sizeofNetNSIDMessage = 4
+
+var errInvalidNetNSIDMessage = errors.New("rtnetlink NetNSIDMessage is invalid or too short")
+
+// A NetNSIDMessage is a route netlink message to query the identifier
+// (netnsid) a network namespace is known by in the namespace of the Conn.
+type NetNSIDMessage struct {
+	// Always set to AF_UNSPEC (0)
+	Family uint8
+
+	// The netnsid of the network namespace, NETNSA_NSID_NOT_ASSIGNED (-1)
+	// if the kernel did not assign one
+	NSID *int32
+
+	// The network namespace to query, only used in requests
+	NetNS *NetNS
+}
+
+// MarshalBinary marshals a NetNSIDMessage into a byte slice.
+func (m *NetNSIDMessage) MarshalBinary() ([]byte, error) {
+	b := make([]byte, sizeofNetNSIDMessage)
+	b[0] = m.Family
+
+	ae := netlink.NewAttributeEncoder()
+	ae.ByteOrder = nativeEndian
+
+	if m.NSID != nil {
+		ae.Int32(unix.NETNSA_NSID, *m.NSID)
+	}
+	if m.NetNS != nil {
+		switch typ, v := m.NetNS.value(); typ {
+		case unix.IFLA_NET_NS_FD:
+			ae.Uint32(unix.NETNSA_FD, v)
+		case unix.IFLA_NET_NS_PID:
+			ae.Uint32(unix.NETNSA_PID, v)
+		}
+	}
+
+	a, err := ae.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, a...), nil
+}
+
+// UnmarshalBinary unmarshals the contents of a byte slice into a
+// NetNSIDMessage.
+func (m *NetNSIDMessage) UnmarshalBinary(b []byte) error {
+	if len(b) < sizeofNetNSIDMessage {
+		return errInvalidNetNSIDMessage
+	}
+
+	m.Family = b[0]
+
+	ad, err := netlink.NewAttributeDecoder(b[sizeofNetNSIDMessage:])
+	if err != nil {
+		return err
+	}
+	ad.ByteOrder = nativeEndian
+
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.NETNSA_NSID:
+			v := ad.Int32()
+			m.NSID = &v
+		}
+	}
+
+	return ad.Err()
+}
+
+// rtMessage is an empty method to sattisfy the Message interface.
+func (*NetNSIDMessage) rtMessage() {}
+
+// NetNSID returns the netnsid the network namespace ns is known by in the
+// network namespace of the Conn, or NETNSA_NSID_NOT_ASSIGNED (-1) if the
+// kernel did not assign one.
+func (c *Conn) NetNSID(ns *NetNS) (int32, error) {
+	msgs, err := c.Execute(&NetNSIDMessage{NetNS: ns}, unix.RTM_GETNSID, netlink.Request)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, m := range msgs {
+		if nm, ok := m.(*NetNSIDMessage); ok && nm.NSID != nil {
+			return *nm.NSID, nil
+		}
+	}
+
+	return 0, errors.New("rtnetlink: no netnsid in reply")
+}
+
+// ResolveNetNSID maps a netnsid of the network namespace of the Conn, as
+// reported in LinkAttributes.LinkNetNSID, to a NetNS handle.
+//
+// The kernel offers no way to open a network namespace by its netnsid, so
+// the network namespaces held open by the calling process and those of all
+// running processes are searched. The returned NetNS either refers to a file
+// descriptor of the calling process, which must be kept open for as long as
+// the NetNS is in use, or to a pid. ErrNetNSIDUnresolvable is returned if no
+// match is found.
+func (c *Conn) ResolveNetNSID(id int32) (*NetNS, error) {
+	if id < 0 {
+		return nil, ErrNetNSIDUnresolvable
+	}
+
+	// Each network namespace only needs to be queried once, no matter how
+	// many handles refer to it.
+	seen := make(map[string]bool)
+	match := func(ns *NetNS, path string) bool {
+		target, err := os.Readlink(path)
+		if err != nil || !strings.HasPrefix(target, "net:[") || seen[target] {
+			return false
+		}
+		seen[target] = true
+
+		// Processes can exit while being searched, so errors only rule out
+		// the candidate.
+		nsid, err := c.NetNSID(ns)
+		return err == nil && nsid == id
+	}
+
+	fds, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return nil, err
+	}
+	for _, fd := range fds {
+		v, err := strconv.ParseUint(fd.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+
+		ns := NetNSForFD(uint32(v))
+		if match(ns, filepath.Join("/proc/self/fd", fd.Name())) {
+			return ns, nil
+		}
+	}
+
+	procs, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range procs {
+		v, err := strconv.ParseUint(p.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+
+		ns := NetNSForPID(uint32(v))
+		if match(ns, filepath.Join("/proc", p.Name(), "ns/net")) {
+			return ns, nil
+		}
+	}
+
+	return nil, ErrNetNSIDUnresolvable
+}
@@ -0,0 +1,122 @@
+//go:build integration
+// +build integration
+
+package rtnetlink
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/jsimonetti/rtnetlink/v2/internal/testutils"
+	"github.com/mdlayher/netlink"
+	"golang.org/x/sys/unix"
+)
+
+func TestResolveNetNSID(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	peerNS := testutils.NetNS(t)
+
+	const vethIndex = 1500
+
+	// Create a veth pair with its peer in another network namespace, which
+	// makes the kernel assign a netnsid to that namespace.
+	peer, err := (&LinkMessage{
+		Index: vethIndex + 1,
+		Attributes: &LinkAttributes{
+			NetNS: NetNSForFD(uint32(peerNS)),
+		},
+	}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode veth peer: %v", err)
+	}
+
+	ae := netlink.NewAttributeEncoder()
+	ae.Bytes(1, peer) // VETH_INFO_PEER
+	data, err := ae.Encode()
+	if err != nil {
+		t.Fatalf("failed to encode veth data: %v", err)
+	}
+
+	if err := conn.Link.New(&LinkMessage{
+		Index: vethIndex,
+		Attributes: &LinkAttributes{
+			Info: &LinkInfo{
+				Kind: "veth",
+				Data: &LinkData{Name: "veth", Data: data},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("failed to create veth pair: %v", err)
+	}
+	defer conn.Link.Delete(vethIndex)
+
+	link, err := conn.Link.Get(vethIndex)
+	if err != nil {
+		t.Fatalf("failed to get veth: %v", err)
+	}
+	if link.Attributes.LinkNetNSID == nil {
+		t.Fatal("expected the veth to report the netnsid of its peer")
+	}
+
+	ns, err := conn.ResolveNetNSID(*link.Attributes.LinkNetNSID)
+	if err != nil {
+		t.Fatalf("failed to resolve netnsid: %v", err)
+	}
+
+	typ, v := ns.value()
+	got, err := os.Readlink(fmt.Sprintf("/proc/self/fd/%d", v))
+	if err != nil || typ != unix.IFLA_NET_NS_FD {
+		t.Fatalf("expected a file descriptor handle, got type %d value %d", typ, v)
+	}
+	want, err := os.Readlink(fmt.Sprintf("/proc/self/fd/%d", peerNS))
+	if err != nil {
+		t.Fatalf("failed to read peer netns: %v", err)
+	}
+	if want != got {
+		t.Fatalf("unexpected network namespace: want %s, got %s", want, got)
+	}
+
+	if _, err := conn.ResolveNetNSID(-1); err != ErrNetNSIDUnresolvable {
+		t.Fatalf("unexpected error for an unassigned netnsid: %v", err)
+	}
+}
+
+func TestNSManagerReusedFD(t *testing.T) {
+	var m NSManager
+	defer m.Close()
+
+	fd, other := testutils.NetNS(t), testutils.NetNS(t)
+
+	c, err := m.Conn(NetNSForFD(uint32(fd)))
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+
+	// Make fd refer to the other network namespace, as if it was closed and
+	// its number reused.
+	if err := unix.Dup3(other, fd, unix.O_CLOEXEC); err != nil {
+		t.Fatalf("failed to duplicate fd: %v", err)
+	}
+
+	reused, err := m.Conn(NetNSForFD(uint32(fd)))
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+	if reused == c {
+		t.Fatal("expected a new connection for a reused fd number")
+	}
+
+	same, err := m.Conn(NetNSForFD(uint32(other)))
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+	if same != reused {
+		t.Fatal("expected the same connection for the same network namespace")
+	}
+}
@@ -0,0 +1,73 @@
+package rtnetlink
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNetNSIDMessageMarshalBinary(t *testing.T) {
+	skipBigEndian(t)
+
+	tests := []struct {
+		name string
+		m    *NetNSIDMessage
+		b    []byte
+	}{
+		{
+			name: "empty",
+			m:    &NetNSIDMessage{},
+			b:    []byte{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name: "fd",
+			m:    &NetNSIDMessage{NetNS: NetNSForFD(3)},
+			b: []byte{
+				0x00, 0x00, 0x00, 0x00,
+				0x08, 0x00, 0x03, 0x00, 0x03, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			name: "pid",
+			m:    &NetNSIDMessage{NetNS: NetNSForPID(1)},
+			b: []byte{
+				0x00, 0x00, 0x00, 0x00,
+				0x08, 0x00, 0x02, 0x00, 0x01, 0x00, 0x00, 0x00,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.m.MarshalBinary()
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("unexpected Message bytes:\n- want: [%# x]\n-  got: [%# x]", want, got)
+			}
+		})
+	}
+}
+
+func TestNetNSIDMessageUnmarshalBinary(t *testing.T) {
+	skipBigEndian(t)
+
+	var m NetNSIDMessage
+	if err := m.UnmarshalBinary([]byte{0x00}); err == nil {
+		t.Fatal("expected an error for a short message, but none occurred")
+	}
+
+	// NETNSA_NSID 5 followed by NETNSA_CURRENT_NSID, which is ignored.
+	b := []byte{
+		0x00, 0x00, 0x00, 0x00,
+		0x08, 0x00, 0x01, 0x00, 0x05, 0x00, 0x00, 0x00,
+		0x08, 0x00, 0x05, 0x00, 0xff, 0xff, 0xff, 0xff,
+	}
+	if err := m.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if m.NSID == nil || *m.NSID != 5 {
+		t.Fatalf("unexpected netnsid: %v", m.NSID)
+	}
+}
@@ -1,10 +1,13 @@
 package rtnetlink
 
 import (
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"net"
+	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
@@ -23,15 +26,15 @@ var (
 var _ Message = &RouteMessage{}
 
 type RouteMessage struct {
-	Family    uint8 // Address family (current unix.AF_INET or unix.AF_INET6)
-	DstLength uint8 // Length of destination prefix
-	SrcLength uint8 // Length of source prefix
-	Tos       uint8 // TOS filter
-	Table     uint8 // Routing table ID
-	Protocol  uint8 // Routing protocol
-	Scope     uint8 // Distance to the destination
-	Type      uint8 // Route type
-	Flags     uint32
+	Family    uint8  // Address family (current unix.AF_INET or unix.AF_INET6)
+	DstLength uint8  // Length of destination prefix
+	SrcLength uint8  // Length of source prefix
+	Tos       uint8  // TOS filter
+	Table     uint8  // Routing table ID
+	Protocol  uint8  // Routing protocol, see RouteProtocol
+	Scope     uint8  // Distance to the destination
+	Type      uint8  // Route type
+	Flags     uint32 // Route flags, see RouteFlags
 
 	Attributes RouteAttributes
 }
@@ -74,7 +77,7 @@ func (m *RouteMessage) UnmarshalBinary(b []byte) error {
 	m.SrcLength = uint8(b[2])
 	m.Tos = uint8(b[3])
 	m.Table = uint8(b[4])
-	m.Protocol = uint8(b[5])
+	m.Protocol = b[5]
 	m.Scope = uint8(b[6])
 	m.Type = uint8(b[7])
 	m.Flags = nativeEndian.Uint32(b[8:12])
@@ -103,12 +106,43 @@ func (m *RouteMessage) UnmarshalBinary(b []byte) error {
 // rtMessage is an empty method to sattisfy the Message interface.
 func (*RouteMessage) rtMessage() {}
 
+// IsOffloaded reports whether the route is programmed into hardware, as
+// indicated by the RTM_F_OFFLOAD flag. Routes that are trapped to the CPU or
+// that failed to be offloaded carry RouteFlagTrap or RouteFlagOffloadFailed
+// instead.
+func (m *RouteMessage) IsOffloaded() bool {
+	return RouteFlags(m.Flags)&RouteFlagOffload != 0
+}
+
+// Validate verifies that the prefix lengths and the destination and source
+// addresses of an IPv4 or IPv6 RouteMessage match its address family.
+func (m *RouteMessage) Validate() error {
+	if err := validatePrefix(m.Family, m.DstLength); err != nil {
+		return err
+	}
+
+	if err := validatePrefix(m.Family, m.SrcLength); err != nil {
+		return err
+	}
+
+	if err := validateIP(m.Family, m.Attributes.Dst); err != nil {
+		return err
+	}
+
+	return validateIP(m.Family, m.Attributes.Src)
+}
+
 type RouteService struct {
 	c *Conn
 }
 
 func (r *RouteService) execute(m Message, family uint16, flags netlink.HeaderFlags) ([]RouteMessage, error) {
-	msgs, err := r.c.Execute(m, family, flags)
+	return r.executeContext(context.Background(), m, family, flags)
+}
+
+// executeContext is like execute, but bounded by ctx.
+func (r *RouteService) executeContext(ctx context.Context, m Message, family uint16, flags netlink.HeaderFlags) ([]RouteMessage, error) {
+	msgs, err := r.c.ExecuteContext(ctx, m, family, flags)
 
 	routes := make([]RouteMessage, len(msgs))
 	for i := range msgs {
@@ -120,6 +154,10 @@ func (r *RouteService) execute(m Message, family uint16, flags netlink.HeaderFla
 
 // Add new route
 func (r *RouteService) Add(req *RouteMessage) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
 	flags := netlink.Request | netlink.Create | netlink.Acknowledge | netlink.Excl
 	_, err := r.c.Execute(req, unix.RTM_NEWROUTE, flags)
 
@@ -128,20 +166,209 @@ func (r *RouteService) Add(req *RouteMessage) error {
 
 // Replace or add new route
 func (r *RouteService) Replace(req *RouteMessage) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
 	flags := netlink.Request | netlink.Create | netlink.Replace | netlink.Acknowledge
 	_, err := r.c.Execute(req, unix.RTM_NEWROUTE, flags)
 
 	return err
 }
 
+// Modify changes an existing route, preserving the attributes of the route
+// that are not set in req.
+//
+// The route to change is looked up by the family, table, TOS and destination
+// prefix of req. Every non-zero field of req, other than those used for the
+// lookup, replaces the value of the existing route. Changing the priority
+// (metric) of a route replaces the old route with a new one, as the kernel
+// treats it as part of the route key.
+//
+// Modify is a read-modify-write operation and is not atomic: changes made to
+// the route by others between the lookup and the update are lost.
+func (r *RouteService) Modify(req *RouteMessage) error {
+	routes, err := r.List()
+	if err != nil {
+		return err
+	}
+
+	var existing *RouteMessage
+	for i := range routes {
+		if !routeKeyEqual(&routes[i], req) {
+			continue
+		}
+		if existing != nil {
+			return fmt.Errorf("rtnetlink: multiple routes match %s/%d, cannot modify", req.Attributes.Dst, req.DstLength)
+		}
+		existing = &routes[i]
+	}
+	if existing == nil {
+		return fmt.Errorf("rtnetlink: no route matches %s/%d", req.Attributes.Dst, req.DstLength)
+	}
+
+	m := mergeRoute(existing, req)
+	if err := r.Replace(m); err != nil {
+		return err
+	}
+
+	if m.Attributes.Priority != existing.Attributes.Priority {
+		return r.Delete(existing)
+	}
+
+	return nil
+}
+
+// routeTable returns the table of a route, which is held in RTA_TABLE for
+// tables with an ID above 255. An unset table refers to the main table.
+func routeTable(m *RouteMessage) uint32 {
+	switch {
+	case m.Attributes.Table != 0:
+		return m.Attributes.Table
+	case m.Table != 0:
+		return uint32(m.Table)
+	default:
+		return unix.RT_TABLE_MAIN
+	}
+}
+
+// routeKeyEqual reports whether route a matches the lookup key of req.
+func routeKeyEqual(a, req *RouteMessage) bool {
+	return a.Family == req.Family &&
+		a.Tos == req.Tos &&
+		a.DstLength == req.DstLength &&
+		routeTable(a) == routeTable(req) &&
+		a.Attributes.Dst.Equal(req.Attributes.Dst)
+}
+
+// mergeRoute returns a copy of existing with the non-zero fields of req
+// applied to it.
+func mergeRoute(existing, req *RouteMessage) *RouteMessage {
+	m := *existing
+	// Flags of a dumped route describe its state and are not meant to be
+	// sent back to the kernel.
+	m.Flags = req.Flags
+
+	if req.SrcLength != 0 {
+		m.SrcLength = req.SrcLength
+	}
+	if req.Protocol != 0 {
+		m.Protocol = req.Protocol
+	}
+	if req.Scope != 0 {
+		m.Scope = req.Scope
+	}
+	if req.Type != 0 {
+		m.Type = req.Type
+	}
+
+	a, e := &m.Attributes, &req.Attributes
+	if e.Src != nil {
+		a.Src = e.Src
+	}
+	if e.Gateway != nil {
+		a.Gateway = e.Gateway
+	}
+	if e.OutIface != 0 {
+		a.OutIface = e.OutIface
+	}
+	if e.IIF != nil {
+		a.IIF = e.IIF
+	}
+	if e.Priority != 0 {
+		a.Priority = e.Priority
+	}
+	if e.Mark != 0 {
+		a.Mark = e.Mark
+	}
+	if e.Flow != nil {
+		a.Flow = e.Flow
+	}
+	if e.Pref != nil {
+		a.Pref = e.Pref
+	}
+	if e.Expires != nil {
+		a.Expires = e.Expires
+	}
+	if e.Metrics != nil {
+		a.Metrics = e.Metrics
+	}
+	if len(e.Multipath) != 0 {
+		a.Multipath = e.Multipath
+	}
+	if e.Via != nil {
+		a.Via = e.Via
+		// the kernel rejects a gateway alongside a via nexthop
+		a.Gateway = nil
+	} else if e.Gateway != nil {
+		a.Via = nil
+	}
+	if e.Encap != nil {
+		a.Encap = e.Encap
+	}
+	if e.Sport != nil {
+		a.Sport = e.Sport
+	}
+	if e.Dport != nil {
+		a.Dport = e.Dport
+	}
+	if e.NHID != nil {
+		a.NHID = e.NHID
+	}
+	if a.NHID != nil {
+		// the nexthop object defines the nexthop of the route, the kernel
+		// rejects other nexthop attributes alongside it
+		a.OutIface = 0
+		a.Gateway = nil
+		a.Via = nil
+		a.Encap = nil
+		a.Multipath = nil
+	}
+
+	return &m
+}
+
 // Delete existing route
+//
+// When the route references a nexthop object through Attributes.NHID, the
+// route is matched by its nexthop id. Any output interface, gateway or
+// multipath attributes, as reported for such routes by the kernel, are left
+// out of the request as the kernel rejects them alongside a nexthop id.
 func (r *RouteService) Delete(req *RouteMessage) error {
+	if req.Attributes.NHID != nil {
+		m := *req
+		m.Attributes.OutIface = 0
+		m.Attributes.Gateway = nil
+		m.Attributes.Multipath = nil
+		req = &m
+	}
+
 	flags := netlink.Request | netlink.Acknowledge
 	_, err := r.c.Execute(req, unix.RTM_DELROUTE, flags)
 
 	return err
 }
 
+// DeleteByNHID deletes all routes that reference the nexthop object with the
+// given id.
+func (r *RouteService) DeleteByNHID(nhid uint32) error {
+	routes, err := r.List()
+	if err != nil {
+		return err
+	}
+
+	for i := range routes {
+		if id := routes[i].Attributes.NHID; id == nil || *id != nhid {
+			continue
+		}
+		if err := r.Delete(&routes[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Get Route(s)
 func (r *RouteService) Get(req *RouteMessage) ([]RouteMessage, error) {
 	flags := netlink.Request | netlink.DumpFiltered
@@ -150,8 +377,153 @@ func (r *RouteService) Get(req *RouteMessage) ([]RouteMessage, error) {
 
 // List all routes
 func (r *RouteService) List() ([]RouteMessage, error) {
+	return r.ListContext(context.Background())
+}
+
+// ListFunc calls fn for each route. The whole dump is received before the
+// first call, but the routes are decoded one at a time and are not
+// collected, so fn can filter them without retaining every RouteMessage. The
+// iteration stops at the first error returned by fn, which is returned
+// unless it is ErrStopList.
+func (r *RouteService) ListFunc(fn func(*RouteMessage) error) error {
 	flags := netlink.Request | netlink.Dump
-	return r.execute(&RouteMessage{}, unix.RTM_GETROUTE, flags)
+	return r.c.executeFunc(&RouteMessage{}, unix.RTM_GETROUTE, flags, func(m Message) error {
+		return fn(m.(*RouteMessage))
+	})
+}
+
+// ListContext is like List, but the dump is bounded by ctx. See
+// Conn.ExecuteContext for details.
+func (r *RouteService) ListContext(ctx context.Context) ([]RouteMessage, error) {
+	flags := netlink.Request | netlink.Dump
+	return r.executeContext(ctx, &RouteMessage{}, unix.RTM_GETROUTE, flags)
+}
+
+// ListByProtocol retrieves all routes installed by the given protocol.
+func (r *RouteService) ListByProtocol(p RouteProtocol) ([]RouteMessage, error) {
+	routes, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := routes[:0]
+	for _, route := range routes {
+		if RouteProtocol(route.Protocol) == p {
+			filtered = append(filtered, route)
+		}
+	}
+
+	return filtered, nil
+}
+
+// RouteFlags is a bitmask of RTM_F_* route message flags.
+type RouteFlags uint32
+
+// Constants that represent the flags of a route message
+const (
+	RouteFlagNotify        RouteFlags = unix.RTM_F_NOTIFY         // notify the user of route changes
+	RouteFlagCloned        RouteFlags = unix.RTM_F_CLONED         // route is cloned
+	RouteFlagEqualize      RouteFlags = unix.RTM_F_EQUALIZE       // multipath equalizer (unused)
+	RouteFlagPrefix        RouteFlags = unix.RTM_F_PREFIX         // prefix addresses
+	RouteFlagLookupTable   RouteFlags = unix.RTM_F_LOOKUP_TABLE   // set the table in a get response to the looked up table
+	RouteFlagFIBMatch      RouteFlags = unix.RTM_F_FIB_MATCH      // return the full FIB lookup match in a get response
+	RouteFlagOffload       RouteFlags = unix.RTM_F_OFFLOAD        // route is offloaded
+	RouteFlagTrap          RouteFlags = unix.RTM_F_TRAP           // route is trapping packets
+	RouteFlagOffloadFailed RouteFlags = unix.RTM_F_OFFLOAD_FAILED // route offload failed
+)
+
+var routeFlagNames = []struct {
+	flag RouteFlags
+	name string
+}{
+	{RouteFlagNotify, "notify"},
+	{RouteFlagCloned, "cloned"},
+	{RouteFlagEqualize, "equalize"},
+	{RouteFlagPrefix, "prefix"},
+	{RouteFlagLookupTable, "lookup_table"},
+	{RouteFlagFIBMatch, "fib_match"},
+	{RouteFlagOffload, "offload"},
+	{RouteFlagTrap, "trap"},
+	{RouteFlagOffloadFailed, "offload_failed"},
+}
+
+// String returns the names of the flags set, separated by "|". Bits without
+// a known name are returned as a hexadecimal value.
+func (f RouteFlags) String() string {
+	if f == 0 {
+		return "0"
+	}
+
+	var names []string
+	for _, n := range routeFlagNames {
+		if f&n.flag != 0 {
+			names = append(names, n.name)
+			f &^= n.flag
+		}
+	}
+	if f != 0 {
+		names = append(names, fmt.Sprintf("%#x", uint32(f)))
+	}
+
+	return strings.Join(names, "|")
+}
+
+// RouteProtocol identifies the originator of a route.
+type RouteProtocol uint8
+
+// routeProtocolNames holds the names of the well-known route protocols.
+var routeProtocolNames = map[RouteProtocol]string{
+	unix.RTPROT_UNSPEC:     "unspec",
+	unix.RTPROT_REDIRECT:   "redirect",
+	unix.RTPROT_KERNEL:     "kernel",
+	unix.RTPROT_BOOT:       "boot",
+	unix.RTPROT_STATIC:     "static",
+	unix.RTPROT_GATED:      "gated",
+	unix.RTPROT_RA:         "ra",
+	unix.RTPROT_MRT:        "mrt",
+	unix.RTPROT_ZEBRA:      "zebra",
+	unix.RTPROT_BIRD:       "bird",
+	unix.RTPROT_DNROUTED:   "dnrouted",
+	unix.RTPROT_XORP:       "xorp",
+	unix.RTPROT_NTK:        "ntk",
+	unix.RTPROT_DHCP:       "dhcp",
+	unix.RTPROT_MROUTED:    "mrouted",
+	unix.RTPROT_KEEPALIVED: "keepalived",
+	unix.RTPROT_BABEL:      "babel",
+	unix.RTPROT_OPENR:      "openr",
+	unix.RTPROT_BGP:        "bgp",
+	unix.RTPROT_ISIS:       "isis",
+	unix.RTPROT_OSPF:       "ospf",
+	unix.RTPROT_RIP:        "rip",
+	unix.RTPROT_EIGRP:      "eigrp",
+}
+
+// registeredRouteProtocols is the global map of registered route protocol names
+var registeredRouteProtocols = make(map[RouteProtocol]string)
+
+// RegisterRouteProtocol registers a name for an application defined route
+// protocol value, which is then returned by RouteProtocol.String. A
+// registered name takes precedence over the well-known name of a value.
+//
+// This function is not threadsafe. This should not be used after Dial
+func RegisterRouteProtocol(value uint8, name string) error {
+	p := RouteProtocol(value)
+	if n, ok := registeredRouteProtocols[p]; ok {
+		return fmt.Errorf("route protocol %d already registered as %s", value, n)
+	}
+	registeredRouteProtocols[p] = name
+	return nil
+}
+
+// String returns the registered or well-known name of the route protocol.
+func (p RouteProtocol) String() string {
+	if n, ok := registeredRouteProtocols[p]; ok {
+		return n
+	}
+	if n, ok := routeProtocolNames[p]; ok {
+		return n
+	}
+	return fmt.Sprintf("unknown RouteProtocol value (%d)", p)
 }
 
 type RouteAttributes struct {
@@ -159,16 +531,63 @@ type RouteAttributes struct {
 	Src       net.IP
 	Gateway   net.IP
 	OutIface  uint32
+	IIF       *uint32
 	Priority  uint32
 	Table     uint32
 	Mark      uint32
+	Flow      *uint32
+	NHID      *uint32
 	Pref      *uint8
 	Expires   *uint32
 	Metrics   *RouteMetrics
 	Multipath []NextHop
+
+	// Via is a gateway of a different address family than the route, such
+	// as an IPv6 nexthop of an IPv4 route
+	Via *RouteVia
+
+	// CacheInfo holds the cache statistics of a route reported by the
+	// kernel, it is never encoded
+	CacheInfo *RouteCacheInfo
+
+	// Encap is the MPLS encapsulation of a single path route, multipath
+	// routes carry it per NextHop
+	Encap *RouteEncap
+
+	// Sport and Dport are the transport layer ports of a route lookup with
+	// Get, used to resolve routes selected by rules with port ranges
+	Sport *uint16
+	Dport *uint16
+}
+
+// ExpiresDuration returns the remaining lifetime of the route held by
+// Expires, or 0 if Expires is not set.
+func (a *RouteAttributes) ExpiresDuration() time.Duration {
+	if a.Expires == nil {
+		return 0
+	}
+	return time.Duration(*a.Expires) * time.Second
+}
+
+// ExpiresFrom returns ref advanced by the remaining lifetime of the route
+// held by Expires, or the zero time if Expires is not set. The kernel reports
+// only the remaining lifetime and no timestamp is kept at decode, so the
+// result is only as accurate as ref; pass the time the route was received.
+func (a *RouteAttributes) ExpiresFrom(ref time.Time) time.Time {
+	if a.Expires == nil {
+		return time.Time{}
+	}
+	return ref.Add(a.ExpiresDuration())
 }
 
 func (a *RouteAttributes) decode(ad *netlink.AttributeDecoder) error {
+	// The encapsulation can only be decoded once its type is known, which may
+	// follow it.
+	var (
+		encapType uint16
+		encapBuf  []byte
+	)
+
 	for ad.Next() {
 		switch ad.Type() {
 		case unix.RTA_UNSPEC:
@@ -181,12 +600,21 @@ func (a *RouteAttributes) decode(ad *netlink.AttributeDecoder) error {
 			ad.Do(decodeIP(&a.Gateway))
 		case unix.RTA_OIF:
 			a.OutIface = ad.Uint32()
+		case unix.RTA_IIF:
+			iif := ad.Uint32()
+			a.IIF = &iif
 		case unix.RTA_PRIORITY:
 			a.Priority = ad.Uint32()
 		case unix.RTA_TABLE:
 			a.Table = ad.Uint32()
 		case unix.RTA_MARK:
 			a.Mark = ad.Uint32()
+		case unix.RTA_FLOW:
+			flow := ad.Uint32()
+			a.Flow = &flow
+		case unix.RTA_NH_ID:
+			nhid := ad.Uint32()
+			a.NHID = &nhid
 		case unix.RTA_EXPIRES:
 			timeout := ad.Uint32()
 			a.Expires = &timeout
@@ -198,9 +626,28 @@ func (a *RouteAttributes) decode(ad *netlink.AttributeDecoder) error {
 		case unix.RTA_PREF:
 			pref := ad.Uint8()
 			a.Pref = &pref
+		case unix.RTA_VIA:
+			a.Via = &RouteVia{}
+			ad.Do(a.Via.unmarshalBinary)
+		case unix.RTA_CACHEINFO:
+			a.CacheInfo = &RouteCacheInfo{}
+			ad.Do(a.CacheInfo.unmarshalBinary)
+		case unix.RTA_ENCAP_TYPE:
+			encapType = ad.Uint16()
+		case unix.RTA_ENCAP:
+			encapBuf = ad.Bytes()
+		case unix.RTA_SPORT:
+			ad.Do(decodePort(&a.Sport))
+		case unix.RTA_DPORT:
+			ad.Do(decodePort(&a.Dport))
 		}
 	}
 
+	if encapType == unix.LWTUNNEL_ENCAP_MPLS && encapBuf != nil {
+		a.Encap = &RouteEncap{}
+		return a.Encap.decode(encapBuf)
+	}
+
 	return nil
 }
 
@@ -221,6 +668,10 @@ func (a *RouteAttributes) encode(ae *netlink.AttributeEncoder) error {
 		ae.Uint32(unix.RTA_OIF, a.OutIface)
 	}
 
+	if a.IIF != nil {
+		ae.Uint32(unix.RTA_IIF, *a.IIF)
+	}
+
 	if a.Priority != 0 {
 		ae.Uint32(unix.RTA_PRIORITY, a.Priority)
 	}
@@ -233,6 +684,14 @@ func (a *RouteAttributes) encode(ae *netlink.AttributeEncoder) error {
 		ae.Uint32(unix.RTA_MARK, a.Mark)
 	}
 
+	if a.Flow != nil {
+		ae.Uint32(unix.RTA_FLOW, *a.Flow)
+	}
+
+	if a.NHID != nil {
+		ae.Uint32(unix.RTA_NH_ID, *a.NHID)
+	}
+
 	if a.Pref != nil {
 		ae.Uint8(unix.RTA_PREF, *a.Pref)
 	}
@@ -249,9 +708,124 @@ func (a *RouteAttributes) encode(ae *netlink.AttributeEncoder) error {
 		ae.Do(unix.RTA_MULTIPATH, a.encodeMultipath)
 	}
 
+	if a.Via != nil {
+		ae.Do(unix.RTA_VIA, a.Via.marshalBinary)
+	}
+
+	if a.Encap != nil {
+		a.Encap.encode(ae)
+	}
+
+	if a.Sport != nil {
+		ae.Do(unix.RTA_SPORT, encodePort(*a.Sport))
+	}
+
+	if a.Dport != nil {
+		ae.Do(unix.RTA_DPORT, encodePort(*a.Dport))
+	}
+
 	return nil
 }
 
+// RouteCacheInfo contains the cache statistics of a route, the leading
+// fields of the struct rta_cacheinfo of the kernel.
+type RouteCacheInfo struct {
+	ClntRef uint32
+	LastUse uint32 // in jiffies
+	Expires int32  // in jiffies, 0 if the route does not expire
+	Error   uint32
+	Used    uint32
+}
+
+// sizeofRouteCacheInfo is the size of the fields of struct rta_cacheinfo
+// decoded into a RouteCacheInfo.
+const sizeofRouteCacheInfo = 20
+
+func (c *RouteCacheInfo) unmarshalBinary(b []byte) error {
+	if len(b) < sizeofRouteCacheInfo {
+		return errInvalidRouteMessageAttr
+	}
+
+	c.ClntRef = nativeEndian.Uint32(b[0:4])
+	c.LastUse = nativeEndian.Uint32(b[4:8])
+	c.Expires = int32(nativeEndian.Uint32(b[8:12]))
+	c.Error = nativeEndian.Uint32(b[12:16])
+	c.Used = nativeEndian.Uint32(b[16:20])
+
+	return nil
+}
+
+// RouteVia is a gateway address along with its address family, the struct
+// rtvia of the kernel.
+type RouteVia struct {
+	Family uint16
+	Addr   net.IP
+}
+
+// addrLen returns the expected length of the address of family, or 0 for an
+// unsupported family.
+func (v *RouteVia) addrLen() int {
+	switch v.Family {
+	case unix.AF_INET:
+		return net.IPv4len
+	case unix.AF_INET6:
+		return net.IPv6len
+	}
+	return 0
+}
+
+func (v *RouteVia) marshalBinary() ([]byte, error) {
+	addr := v.Addr
+	if v.Family == unix.AF_INET {
+		addr = addr.To4()
+	}
+	if l := v.addrLen(); l == 0 || len(addr) != l {
+		return nil, fmt.Errorf("rtnetlink: cannot encode via address %s for family %d", v.Addr, v.Family)
+	}
+
+	b := make([]byte, 2, 2+len(addr))
+	nativeEndian.PutUint16(b, v.Family)
+	return append(b, addr...), nil
+}
+
+func (v *RouteVia) unmarshalBinary(b []byte) error {
+	if len(b) < 2 {
+		return errInvalidRouteMessageAttr
+	}
+
+	v.Family = nativeEndian.Uint16(b[:2])
+	if l := v.addrLen(); l == 0 || len(b)-2 != l {
+		return errInvalidRouteMessageAttr
+	}
+	v.Addr = make(net.IP, len(b)-2)
+	copy(v.Addr, b[2:])
+
+	return nil
+}
+
+// encodePort is a helper for encoding a transport layer port in network byte
+// order. It should be used with (*netlink.AttributeEncoder).Do.
+func encodePort(port uint16) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		b := make([]byte, 2)
+		binary.BigEndian.PutUint16(b, port)
+		return b, nil
+	}
+}
+
+// decodePort is a helper for decoding a transport layer port in network byte
+// order. It should be used with (*netlink.AttributeDecoder).Do.
+func decodePort(port **uint16) func(b []byte) error {
+	return func(b []byte) error {
+		if len(b) != 2 {
+			return errInvalidRouteMessageAttr
+		}
+		v := binary.BigEndian.Uint16(b)
+		*port = &v
+		return nil
+	}
+}
+
 // RouteMetrics holds some advanced metrics for a route
 type RouteMetrics struct {
 	AdvMSS   uint32
@@ -259,6 +833,16 @@ type RouteMetrics struct {
 	InitCwnd uint32
 	InitRwnd uint32
 	MTU      uint32
+
+	HopLimit   uint32
+	RTT        uint32 // smoothed round trip time, in units of 1/8 ms
+	RTTVar     uint32 // round trip time variance, in units of 1/4 ms
+	SsThresh   uint32
+	Cwnd       uint32
+	Reordering uint32
+	Window     uint32
+	QuickAck   uint32
+	CCAlgo     string // name of the TCP congestion control algorithm
 }
 
 func (rm *RouteMetrics) decode(ad *netlink.AttributeDecoder) error {
@@ -274,6 +858,24 @@ func (rm *RouteMetrics) decode(ad *netlink.AttributeDecoder) error {
 			rm.InitRwnd = ad.Uint32()
 		case unix.RTAX_MTU:
 			rm.MTU = ad.Uint32()
+		case unix.RTAX_HOPLIMIT:
+			rm.HopLimit = ad.Uint32()
+		case unix.RTAX_RTT:
+			rm.RTT = ad.Uint32()
+		case unix.RTAX_RTTVAR:
+			rm.RTTVar = ad.Uint32()
+		case unix.RTAX_SSTHRESH:
+			rm.SsThresh = ad.Uint32()
+		case unix.RTAX_CWND:
+			rm.Cwnd = ad.Uint32()
+		case unix.RTAX_REORDERING:
+			rm.Reordering = ad.Uint32()
+		case unix.RTAX_WINDOW:
+			rm.Window = ad.Uint32()
+		case unix.RTAX_QUICKACK:
+			rm.QuickAck = ad.Uint32()
+		case unix.RTAX_CC_ALGO:
+			rm.CCAlgo = ad.String()
 		}
 	}
 
@@ -302,6 +904,42 @@ func (rm *RouteMetrics) encode(ae *netlink.AttributeEncoder) error {
 		ae.Uint32(unix.RTAX_MTU, rm.MTU)
 	}
 
+	if rm.HopLimit != 0 {
+		ae.Uint32(unix.RTAX_HOPLIMIT, rm.HopLimit)
+	}
+
+	if rm.RTT != 0 {
+		ae.Uint32(unix.RTAX_RTT, rm.RTT)
+	}
+
+	if rm.RTTVar != 0 {
+		ae.Uint32(unix.RTAX_RTTVAR, rm.RTTVar)
+	}
+
+	if rm.SsThresh != 0 {
+		ae.Uint32(unix.RTAX_SSTHRESH, rm.SsThresh)
+	}
+
+	if rm.Cwnd != 0 {
+		ae.Uint32(unix.RTAX_CWND, rm.Cwnd)
+	}
+
+	if rm.Reordering != 0 {
+		ae.Uint32(unix.RTAX_REORDERING, rm.Reordering)
+	}
+
+	if rm.Window != 0 {
+		ae.Uint32(unix.RTAX_WINDOW, rm.Window)
+	}
+
+	if rm.QuickAck != 0 {
+		ae.Uint32(unix.RTAX_QUICKACK, rm.QuickAck)
+	}
+
+	if rm.CCAlgo != "" {
+		ae.String(unix.RTAX_CC_ALGO, rm.CCAlgo)
+	}
+
 	return nil
 }
 
@@ -445,14 +1083,87 @@ type MPLSNextHop struct {
 // a NextHop.
 func (nh *NextHop) encodeEncap(ae *netlink.AttributeEncoder) error {
 	// TODO: this only handles MPLS encapsulation as that is all we support.
+	ae.Bytes(unix.MPLS_IPTUNNEL_DST, encodeMPLSLabels(nh.MPLS))
+	return nil
+}
+
+// decodeEncap decodes netlink attribute values related to encapsulation into a
+// NextHop.
+func (nh *NextHop) decodeEncap(typ uint16, b []byte) error {
+	if typ != unix.LWTUNNEL_ENCAP_MPLS {
+		// TODO: handle other encapsulation types as needed.
+		return nil
+	}
+
+	ad, err := netlink.NewAttributeDecoder(b)
+	if err != nil {
+		return err
+	}
+
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.MPLS_IPTUNNEL_DST:
+			ad.Do(decodeMPLSLabels(&nh.MPLS))
+		}
+	}
+
+	return ad.Err()
+}
+
+// RouteEncap is the lightweight tunnel encapsulation of a route. Only MPLS
+// encapsulation is supported.
+type RouteEncap struct {
+	// MPLS is the label stack pushed onto the packets of the route
+	MPLS []MPLSNextHop
+
+	// TTL is the TTL of the pushed MPLS header. If unset, the TTL is
+	// propagated from the IP header according to the MPLS sysctls.
+	TTL *uint8
+}
+
+// encode encodes the RTA_ENCAP_TYPE and nested RTA_ENCAP attributes of a
+// RouteEncap.
+func (e *RouteEncap) encode(ae *netlink.AttributeEncoder) {
+	ae.Uint16(unix.RTA_ENCAP_TYPE, unix.LWTUNNEL_ENCAP_MPLS)
+	ae.Nested(unix.RTA_ENCAP, func(nae *netlink.AttributeEncoder) error {
+		nae.Bytes(unix.MPLS_IPTUNNEL_DST, encodeMPLSLabels(e.MPLS))
+		if e.TTL != nil {
+			nae.Uint8(unix.MPLS_IPTUNNEL_TTL, *e.TTL)
+		}
+		return nil
+	})
+}
+
+// decode decodes the nested RTA_ENCAP attributes of an MPLS encapsulation
+// into a RouteEncap.
+func (e *RouteEncap) decode(b []byte) error {
+	ad, err := netlink.NewAttributeDecoder(b)
+	if err != nil {
+		return err
+	}
 
+	for ad.Next() {
+		switch ad.Type() {
+		case unix.MPLS_IPTUNNEL_DST:
+			ad.Do(decodeMPLSLabels(&e.MPLS))
+		case unix.MPLS_IPTUNNEL_TTL:
+			ttl := ad.Uint8()
+			e.TTL = &ttl
+		}
+	}
+
+	return ad.Err()
+}
+
+// encodeMPLSLabels packs an MPLS label stack into its big endian wire format.
+func encodeMPLSLabels(labels []MPLSNextHop) []byte {
 	// Allocate enough space for an MPLS label stack.
 	var (
 		i int
-		b = make([]byte, 4*len(nh.MPLS))
+		b = make([]byte, 4*len(labels))
 	)
 
-	for _, mnh := range nh.MPLS {
+	for _, mnh := range labels {
 		// Pack the following:
 		//  - label: 20 bits
 		//  - traffic class: 3 bits
@@ -472,51 +1183,34 @@ func (nh *NextHop) encodeEncap(ae *netlink.AttributeEncoder) error {
 		i += 4
 	}
 
-	// Finally store the output bytes.
-	ae.Bytes(unix.MPLS_IPTUNNEL_DST, b)
-	return nil
+	return b
 }
 
-// decodeEncap decodes netlink attribute values related to encapsulation into a
-// NextHop.
-func (nh *NextHop) decodeEncap(typ uint16, b []byte) error {
-	if typ != unix.LWTUNNEL_ENCAP_MPLS {
-		// TODO: handle other encapsulation types as needed.
-		return nil
-	}
-
-	// MPLS labels are stored as big endian bytes.
-	ad, err := netlink.NewAttributeDecoder(b)
-	if err != nil {
-		return err
-	}
+// decodeMPLSLabels is a helper for decoding an MPLS label stack stored as big
+// endian bytes. It should be used with (*netlink.AttributeDecoder).Do.
+func decodeMPLSLabels(labels *[]MPLSNextHop) func(b []byte) error {
+	return func(b []byte) error {
+		// Every 4 bytes stores another MPLS label, so make sure the stored
+		// bytes are divisible by exactly 4.
+		if len(b)%4 != 0 {
+			return errInvalidRouteMessageAttr
+		}
 
-	for ad.Next() {
-		switch ad.Type() {
-		case unix.MPLS_IPTUNNEL_DST:
-			// Every 4 bytes stores another MPLS label, so make sure the stored
-			// bytes are divisible by exactly 4.
-			b := ad.Bytes()
-			if len(b)%4 != 0 {
-				return errInvalidRouteMessageAttr
-			}
-
-			for i := 0; i < len(b); i += 4 {
-				n := binary.BigEndian.Uint32(b[i : i+4])
-
-				// For reference, see:
-				// https://en.wikipedia.org/wiki/Multiprotocol_Label_Switching#Operation
-				nh.MPLS = append(nh.MPLS, MPLSNextHop{
-					Label:         int(n) >> 12,
-					TrafficClass:  int(n & 0xe00 >> 9),
-					BottomOfStack: n&0x100 != 0,
-					TTL:           uint8(n & 0xff),
-				})
-			}
+		for i := 0; i < len(b); i += 4 {
+			n := binary.BigEndian.Uint32(b[i : i+4])
+
+			// For reference, see:
+			// https://en.wikipedia.org/wiki/Multiprotocol_Label_Switching#Operation
+			*labels = append(*labels, MPLSNextHop{
+				Label:         int(n) >> 12,
+				TrafficClass:  int(n & 0xe00 >> 9),
+				BottomOfStack: n&0x100 != 0,
+				TTL:           uint8(n & 0xff),
+			})
 		}
-	}
 
-	return ad.Err()
+		return nil
+	}
 }
 
 // A multipathParser parses packed RTNextHop and netlink attributes into
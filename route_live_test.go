@@ -0,0 +1,349 @@
+//go:build integration
+// +build integration
+
+package rtnetlink
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2/internal/testutils"
+	"github.com/mdlayher/netlink"
+	"golang.org/x/sys/unix"
+)
+
+func TestRouteModify(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	const vethIndex = 1600
+
+	createVeth(t, conn, vethIndex, vethIndex+1)
+	defer conn.Link.Delete(vethIndex)
+
+	if err := conn.Link.Set(&LinkMessage{
+		Index:  vethIndex,
+		Flags:  unix.IFF_UP,
+		Change: unix.IFF_UP,
+	}); err != nil {
+		t.Fatalf("failed to set link up: %v", err)
+	}
+
+	local := net.IPv4(192, 0, 2, 1).To4()
+	if err := conn.Address.New(&AddressMessage{
+		Family:       unix.AF_INET,
+		PrefixLength: 24,
+		Index:        vethIndex,
+		Attributes: &AddressAttributes{
+			Address: local,
+			Local:   local,
+		},
+	}); err != nil {
+		t.Fatalf("failed to add address: %v", err)
+	}
+
+	var (
+		dst = net.IPv4(198, 51, 100, 0).To4()
+		gw  = net.IPv4(192, 0, 2, 2).To4()
+	)
+
+	if err := conn.Route.Add(&RouteMessage{
+		Family:    unix.AF_INET,
+		DstLength: 24,
+		Table:     unix.RT_TABLE_MAIN,
+		Protocol:  unix.RTPROT_STATIC,
+		Scope:     unix.RT_SCOPE_UNIVERSE,
+		Type:      unix.RTN_UNICAST,
+		Attributes: RouteAttributes{
+			Dst:      dst,
+			Gateway:  gw,
+			OutIface: vethIndex,
+			Priority: 100,
+		},
+	}); err != nil {
+		t.Fatalf("failed to add route: %v", err)
+	}
+
+	if err := conn.Route.Modify(&RouteMessage{
+		Family:    unix.AF_INET,
+		DstLength: 24,
+		Attributes: RouteAttributes{
+			Dst:      dst,
+			Priority: 200,
+		},
+	}); err != nil {
+		t.Fatalf("failed to modify route: %v", err)
+	}
+
+	routes, err := conn.Route.List()
+	if err != nil {
+		t.Fatalf("failed to list routes: %v", err)
+	}
+
+	var found []RouteMessage
+	for _, r := range routes {
+		if r.DstLength == 24 && r.Attributes.Dst.Equal(dst) {
+			found = append(found, r)
+		}
+	}
+
+	if len(found) != 1 {
+		t.Fatalf("expected exactly 1 route to %s/24, got %d", dst, len(found))
+	}
+	r := found[0]
+	if want, got := uint32(200), r.Attributes.Priority; want != got {
+		t.Fatalf("unexpected route priority, want: %d, got: %d", want, got)
+	}
+	if want, got := gw, r.Attributes.Gateway; !want.Equal(got) {
+		t.Fatalf("unexpected route gateway, want: %s, got: %s", want, got)
+	}
+	if want, got := RouteProtocol(unix.RTPROT_STATIC), RouteProtocol(r.Protocol); want != got {
+		t.Fatalf("unexpected route protocol, want: %s, got: %s", want, got)
+	}
+}
+
+func TestRouteModifyKeepsVia(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	const vethIndex = 1610
+
+	createVeth(t, conn, vethIndex, vethIndex+1)
+	defer conn.Link.Delete(vethIndex)
+
+	if err := conn.Link.Set(&LinkMessage{
+		Index:  vethIndex,
+		Flags:  unix.IFF_UP,
+		Change: unix.IFF_UP,
+	}); err != nil {
+		t.Fatalf("failed to set link up: %v", err)
+	}
+
+	var (
+		dst = net.IPv4(198, 51, 100, 0).To4()
+		via = &RouteVia{Family: unix.AF_INET6, Addr: net.ParseIP("fe80::2")}
+	)
+
+	if err := conn.Route.Add(&RouteMessage{
+		Family:    unix.AF_INET,
+		DstLength: 24,
+		Table:     unix.RT_TABLE_MAIN,
+		Protocol:  unix.RTPROT_STATIC,
+		Scope:     unix.RT_SCOPE_UNIVERSE,
+		Type:      unix.RTN_UNICAST,
+		Attributes: RouteAttributes{
+			Dst:      dst,
+			Via:      via,
+			OutIface: vethIndex,
+			Priority: 100,
+		},
+	}); err != nil {
+		t.Fatalf("failed to add route: %v", err)
+	}
+
+	if err := conn.Route.Modify(&RouteMessage{
+		Family:    unix.AF_INET,
+		DstLength: 24,
+		Attributes: RouteAttributes{
+			Dst:      dst,
+			Priority: 200,
+		},
+	}); err != nil {
+		t.Fatalf("failed to modify route: %v", err)
+	}
+
+	routes, err := conn.Route.List()
+	if err != nil {
+		t.Fatalf("failed to list routes: %v", err)
+	}
+
+	var found []RouteMessage
+	for _, r := range routes {
+		if r.DstLength == 24 && r.Attributes.Dst.Equal(dst) {
+			found = append(found, r)
+		}
+	}
+
+	if len(found) != 1 {
+		t.Fatalf("expected exactly 1 route to %s/24, got %d", dst, len(found))
+	}
+	r := found[0]
+	if want, got := uint32(200), r.Attributes.Priority; want != got {
+		t.Fatalf("unexpected route priority, want: %d, got: %d", want, got)
+	}
+	if diff := cmp.Diff(via, r.Attributes.Via); diff != "" {
+		t.Fatalf("unexpected route via (-want +got):\n%s", diff)
+	}
+}
+
+// testNexthopMessage is a minimal RTM_NEWNEXTHOP request creating a nexthop
+// object for an output interface.
+type testNexthopMessage struct {
+	id, oif uint32
+}
+
+func (m *testNexthopMessage) MarshalBinary() ([]byte, error) {
+	ae := netlink.NewAttributeEncoder()
+	ae.Uint32(unix.NHA_ID, m.id)
+	ae.Uint32(unix.NHA_OIF, m.oif)
+	attrs, err := ae.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	// struct nhmsg
+	return append([]byte{unix.AF_INET, 0, 0, 0, 0, 0, 0, 0}, attrs...), nil
+}
+
+func (m *testNexthopMessage) UnmarshalBinary(_ []byte) error { return nil }
+
+func (*testNexthopMessage) rtMessage() {}
+
+func TestRouteDeleteByNHID(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.Link.Set(&LinkMessage{
+		Index:  lo,
+		Flags:  unix.IFF_UP,
+		Change: unix.IFF_UP,
+	}); err != nil {
+		t.Fatalf("failed to set loopback up: %v", err)
+	}
+
+	const nhid = 10
+
+	if _, err := conn.Execute(&testNexthopMessage{id: nhid, oif: lo}, unix.RTM_NEWNEXTHOP,
+		netlink.Request|netlink.Create|netlink.Excl|netlink.Acknowledge); err != nil {
+		t.Fatalf("failed to create nexthop: %v", err)
+	}
+
+	id := uint32(nhid)
+	dst := net.IPv4(198, 51, 100, 0).To4()
+	if err := conn.Route.Add(&RouteMessage{
+		Family:    unix.AF_INET,
+		DstLength: 24,
+		Table:     unix.RT_TABLE_MAIN,
+		Protocol:  unix.RTPROT_STATIC,
+		Type:      unix.RTN_UNICAST,
+		Attributes: RouteAttributes{
+			Dst:  dst,
+			NHID: &id,
+		},
+	}); err != nil {
+		t.Fatalf("failed to add route: %v", err)
+	}
+
+	count := func() int {
+		t.Helper()
+
+		routes, err := conn.Route.List()
+		if err != nil {
+			t.Fatalf("failed to list routes: %v", err)
+		}
+
+		var n int
+		for _, r := range routes {
+			if r.Attributes.NHID != nil && *r.Attributes.NHID == nhid && r.Attributes.Dst.Equal(dst) {
+				n++
+			}
+		}
+		return n
+	}
+
+	if n := count(); n != 1 {
+		t.Fatalf("expected 1 route referencing nexthop %d, got %d", nhid, n)
+	}
+
+	if err := conn.Route.DeleteByNHID(nhid); err != nil {
+		t.Fatalf("failed to delete routes by nexthop id: %v", err)
+	}
+
+	if n := count(); n != 0 {
+		t.Fatalf("expected no routes referencing nexthop %d, got %d", nhid, n)
+	}
+}
+
+func TestRouteGetWithPorts(t *testing.T) {
+	conn, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatalf("failed to establish netlink socket: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.Link.Set(&LinkMessage{
+		Index:  lo,
+		Flags:  unix.IFF_UP,
+		Change: unix.IFF_UP,
+	}); err != nil {
+		t.Fatalf("failed to set loopback up: %v", err)
+	}
+
+	const table = 100
+
+	// Only reachable through the table selected by the destination port.
+	if err := conn.Route.Add(&RouteMessage{
+		Family:    unix.AF_INET,
+		DstLength: 24,
+		Protocol:  unix.RTPROT_STATIC,
+		Scope:     unix.RT_SCOPE_LINK,
+		Type:      unix.RTN_UNICAST,
+		Attributes: RouteAttributes{
+			Dst:      net.IPv4(198, 51, 100, 0).To4(),
+			OutIface: lo,
+			Table:    table,
+		},
+	}); err != nil {
+		t.Fatalf("failed to add route: %v", err)
+	}
+
+	tbl := uint32(table)
+	if err := conn.Rule.Add(&RuleMessage{
+		Family: unix.AF_INET,
+		Action: unix.FR_ACT_TO_TBL,
+		Attributes: &RuleAttributes{
+			Table:      &tbl,
+			DPortRange: &RulePortRange{Start: 8000, End: 8080},
+		},
+	}); err != nil {
+		t.Fatalf("failed to add rule: %v", err)
+	}
+
+	get := func(dport uint16) ([]RouteMessage, error) {
+		return conn.Route.Get(&RouteMessage{
+			Family:    unix.AF_INET,
+			DstLength: 32,
+			// Report the table the route was found in rather than main.
+			Flags: uint32(RouteFlagLookupTable),
+			Attributes: RouteAttributes{
+				Dst:   net.IPv4(198, 51, 100, 1).To4(),
+				Dport: &dport,
+			},
+		})
+	}
+
+	if _, err := get(443); err == nil {
+		t.Fatal("expected an error resolving a port outside of the rule range, but none occurred")
+	}
+
+	routes, err := get(8080)
+	if err != nil {
+		t.Fatalf("failed to resolve route: %v", err)
+	}
+	if len(routes) != 1 {
+		t.Fatalf("expected one route, got %d", len(routes))
+	}
+	if got := routes[0].Attributes.Table; got != table {
+		t.Fatalf("unexpected table: want %d, got %d", table, got)
+	}
+}
@@ -3,6 +3,7 @@ package rtnetlink
 import (
 	"net"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
@@ -43,6 +44,18 @@ func TestRouteMessageMarshalUnmarshalBinary(t *testing.T) {
 				0x00, 0x00, 0x00, 0x00,
 			},
 		},
+		{
+			name: "notify flag",
+			m: &RouteMessage{
+				Family: unix.AF_INET,
+				Type:   unix.RTN_UNICAST,
+				Flags:  uint32(RouteFlagNotify),
+			},
+			b: []byte{
+				0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+				0x00, 0x01, 0x00, 0x00,
+			},
+		},
 		{
 			name: "full",
 			m: &RouteMessage{
@@ -272,6 +285,36 @@ func TestRouteMessageMarshalRoundTrip(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "multipath IPv4 per-hop gateways",
+			m: &RouteMessage{
+				Attributes: RouteAttributes{
+					Multipath: []NextHop{
+						{
+							Hop: RTNextHop{
+								Length:  16,
+								IfIndex: 1,
+							},
+							Gateway: net.IPv4(10, 0, 0, 2),
+						},
+						{
+							Hop: RTNextHop{
+								Length:  16,
+								IfIndex: 2,
+							},
+							Gateway: net.IPv4(10, 0, 1, 2),
+						},
+						{
+							// A directly connected hop without a gateway.
+							Hop: RTNextHop{
+								Length:  8,
+								IfIndex: 3,
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "multipath IPv6 MPLS",
 			m: &RouteMessage{
@@ -314,6 +357,146 @@ func TestRouteMessageMarshalRoundTrip(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "IPv4 multicast",
+			m: &RouteMessage{
+				Family:    unix.AF_INET,
+				DstLength: 32,
+				SrcLength: 32,
+				Table:     unix.RT_TABLE_MAIN,
+				Type:      unix.RTN_MULTICAST,
+				Attributes: RouteAttributes{
+					Dst:   net.IPv4(239, 1, 1, 1),
+					Src:   net.IPv4(10, 0, 0, 1),
+					IIF:   uint32Ptr(1),
+					Table: unix.RT_TABLE_MAIN,
+					Multipath: []NextHop{
+						{
+							Hop: RTNextHop{
+								Length:  8,
+								IfIndex: 2,
+							},
+						},
+						{
+							Hop: RTNextHop{
+								Length:  8,
+								IfIndex: 3,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "IPv4 realms",
+			m: &RouteMessage{
+				Family:    unix.AF_INET,
+				DstLength: 24,
+				Table:     unix.RT_TABLE_MAIN,
+				Type:      unix.RTN_UNICAST,
+				Attributes: RouteAttributes{
+					Dst:      net.IPv4(192, 0, 2, 0),
+					Gateway:  net.IPv4(198, 51, 100, 1),
+					OutIface: 2,
+					Table:    unix.RT_TABLE_MAIN,
+					// Source realm 1, destination realm 2.
+					Flow: uint32Ptr(1<<16 | 2),
+				},
+			},
+		},
+		{
+			name: "IPv4 lookup ports",
+			m: &RouteMessage{
+				Family:    unix.AF_INET,
+				DstLength: 32,
+				Attributes: RouteAttributes{
+					Dst:   net.IPv4(192, 0, 2, 1),
+					Sport: uint16Ptr(49152),
+					Dport: uint16Ptr(443),
+				},
+			},
+		},
+		{
+			name: "IPv6 router preference",
+			m: &RouteMessage{
+				Family:   unix.AF_INET6,
+				Table:    unix.RT_TABLE_MAIN,
+				Protocol: unix.RTPROT_RA,
+				Type:     unix.RTN_UNICAST,
+				Attributes: RouteAttributes{
+					Gateway:  net.ParseIP("fe80::1"),
+					OutIface: 2,
+					Table:    unix.RT_TABLE_MAIN,
+					// ICMPV6_ROUTER_PREF_HIGH
+					Pref: uint8Ptr(1),
+				},
+			},
+		},
+		{
+			name: "IPv4 metrics",
+			m: &RouteMessage{
+				Family:    unix.AF_INET,
+				DstLength: 24,
+				Attributes: RouteAttributes{
+					Dst: net.IPv4(192, 0, 2, 0),
+					Metrics: &RouteMetrics{
+						AdvMSS:     1460,
+						InitCwnd:   10,
+						InitRwnd:   20,
+						MTU:        1500,
+						HopLimit:   64,
+						RTT:        800,
+						RTTVar:     400,
+						SsThresh:   30,
+						Cwnd:       40,
+						Reordering: 3,
+						Window:     65535,
+						QuickAck:   1,
+						CCAlgo:     "bbr",
+					},
+				},
+			},
+		},
+		{
+			name: "IPv4 MPLS encap",
+			m: &RouteMessage{
+				Family:    unix.AF_INET,
+				DstLength: 24,
+				Table:     unix.RT_TABLE_MAIN,
+				Type:      unix.RTN_UNICAST,
+				Attributes: RouteAttributes{
+					Dst:      net.IPv4(192, 0, 2, 0),
+					Gateway:  net.IPv4(198, 51, 100, 1),
+					OutIface: 2,
+					Table:    unix.RT_TABLE_MAIN,
+					Encap: &RouteEncap{
+						MPLS: []MPLSNextHop{
+							{Label: 100},
+							{Label: 200, BottomOfStack: true},
+						},
+						TTL: uint8Ptr(64),
+					},
+				},
+			},
+		},
+		{
+			name: "IPv4 via IPv6",
+			m: &RouteMessage{
+				Family:    unix.AF_INET,
+				DstLength: 24,
+				Table:     unix.RT_TABLE_MAIN,
+				Type:      unix.RTN_UNICAST,
+				Attributes: RouteAttributes{
+					Dst:      net.IPv4(192, 0, 2, 0),
+					OutIface: 2,
+					Table:    unix.RT_TABLE_MAIN,
+					Via: &RouteVia{
+						Family: unix.AF_INET6,
+						Addr:   net.ParseIP("fe80::1"),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -391,6 +574,171 @@ func TestRouteMessageUnmarshalBinaryErrors(t *testing.T) {
 	}
 }
 
+func TestRouteMessageUnmarshalCacheInfo(t *testing.T) {
+	skipBigEndian(t)
+
+	b := []byte{
+		0x0a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+		// RTA_CACHEINFO
+		0x24, 0x00, 0x0c, 0x00,
+		0x01, 0x00, 0x00, 0x00, // clntref
+		0x10, 0x27, 0x00, 0x00, // lastuse
+		0xe8, 0x03, 0x00, 0x00, // expires
+		0xf3, 0xff, 0xff, 0xff, // error
+		0x05, 0x00, 0x00, 0x00, // used
+		0x00, 0x00, 0x00, 0x00, // id
+		0x00, 0x00, 0x00, 0x00, // ts
+		0x00, 0x00, 0x00, 0x00, // tsage
+	}
+
+	var m RouteMessage
+	if err := m.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	want := &RouteCacheInfo{
+		ClntRef: 1,
+		LastUse: 10000,
+		Expires: 1000,
+		Error:   0xfffffff3,
+		Used:    5,
+	}
+	if diff := cmp.Diff(want, m.Attributes.CacheInfo); diff != "" {
+		t.Fatalf("unexpected cache info (-want +got):\n%s", diff)
+	}
+
+	// The cache info is read-only and never encoded.
+	out, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if diff := cmp.Diff(b[:unix.SizeofRtMsg], out); diff != "" {
+		t.Fatalf("unexpected bytes (-want +got):\n%s", diff)
+	}
+
+	// A truncated cache info is rejected.
+	b[12] = 0x10
+	if err := m.UnmarshalBinary(b[:28]); err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+}
+
+func TestRouteEncapMarshalBinary(t *testing.T) {
+	skipBigEndian(t)
+
+	m := &RouteMessage{
+		Family: unix.AF_INET,
+		Attributes: RouteAttributes{
+			Encap: &RouteEncap{
+				MPLS: []MPLSNextHop{{Label: 100, BottomOfStack: true}},
+				TTL:  uint8Ptr(64),
+			},
+		},
+	}
+
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	want := []byte{
+		0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+		// RTA_ENCAP_TYPE: LWTUNNEL_ENCAP_MPLS
+		0x06, 0x00, 0x15, 0x00, 0x01, 0x00, 0x00, 0x00,
+		// RTA_ENCAP
+		0x14, 0x00, 0x16, 0x80,
+		// MPLS_IPTUNNEL_DST: label 100, bottom of stack
+		0x08, 0x00, 0x01, 0x00, 0x00, 0x06, 0x41, 0x00,
+		// MPLS_IPTUNNEL_TTL
+		0x05, 0x00, 0x02, 0x00, 0x40, 0x00, 0x00, 0x00,
+	}
+	if diff := cmp.Diff(want, b); diff != "" {
+		t.Fatalf("unexpected bytes (-want +got):\n%s", diff)
+	}
+
+	// Encapsulation types other than MPLS are ignored.
+	b[16] = unix.LWTUNNEL_ENCAP_IP
+	var got RouteMessage
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if got.Attributes.Encap != nil {
+		t.Fatalf("unexpected encapsulation: %#v", got.Attributes.Encap)
+	}
+}
+
+func TestRouteViaMarshalBinary(t *testing.T) {
+	skipBigEndian(t)
+
+	v := &RouteVia{Family: unix.AF_INET, Addr: net.IPv4(192, 0, 2, 1)}
+	b, err := v.marshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	// struct rtvia: the family followed by the 4 byte address.
+	want := []byte{0x02, 0x00, 0xc0, 0x00, 0x02, 0x01}
+	if diff := cmp.Diff(want, b); diff != "" {
+		t.Fatalf("unexpected bytes (-want +got):\n%s", diff)
+	}
+
+	// An IPv6 family with an IPv4 sized address is invalid.
+	if err := (&RouteVia{}).unmarshalBinary([]byte{0x0a, 0x00, 0xc0, 0x00, 0x02, 0x01}); err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+}
+
+func TestRouteMessageMarshalBinaryErrors(t *testing.T) {
+	short := net.IP{192, 0, 2}
+
+	tests := []struct {
+		name string
+		a    RouteAttributes
+	}{
+		{
+			name: "short dst",
+			a:    RouteAttributes{Dst: short},
+		},
+		{
+			name: "short src",
+			a:    RouteAttributes{Src: short},
+		},
+		{
+			name: "short gateway",
+			a:    RouteAttributes{Gateway: short},
+		},
+		{
+			name: "short multipath gateway",
+			a: RouteAttributes{
+				Multipath: []NextHop{{Gateway: short}},
+			},
+		},
+		{
+			name: "IPv6 via with IPv4 family",
+			a: RouteAttributes{
+				Via: &RouteVia{Family: unix.AF_INET, Addr: net.ParseIP("fe80::1")},
+			},
+		},
+		{
+			name: "via unknown family",
+			a: RouteAttributes{
+				Via: &RouteVia{Family: unix.AF_UNSPEC, Addr: net.IPv4(192, 0, 2, 1)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &RouteMessage{Attributes: tt.a}
+			if _, err := m.MarshalBinary(); err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
+
 func TestRouteMessageFuzz(t *testing.T) {
 	skipBigEndian(t)
 
@@ -429,3 +777,236 @@ func TestRouteMessageFuzz(t *testing.T) {
 		})
 	}
 }
+
+func TestRouteMessageValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *RouteMessage
+		ok   bool
+	}{
+		{
+			name: "IPv4 destination prefix too long",
+			m: &RouteMessage{
+				Family:    unix.AF_INET,
+				DstLength: 64,
+			},
+		},
+		{
+			name: "IPv6 source prefix too long",
+			m: &RouteMessage{
+				Family:    unix.AF_INET6,
+				SrcLength: 129,
+			},
+		},
+		{
+			name: "IPv6 destination with IPv4 family",
+			m: &RouteMessage{
+				Family:    unix.AF_INET,
+				DstLength: 32,
+				Attributes: RouteAttributes{
+					Dst: net.ParseIP("2001:db8::"),
+				},
+			},
+		},
+		{
+			name: "OK IPv6",
+			m: &RouteMessage{
+				Family:    unix.AF_INET6,
+				DstLength: 32,
+				Attributes: RouteAttributes{
+					Dst: net.ParseIP("2001:db8::"),
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "OK unknown family",
+			m: &RouteMessage{
+				Family:    unix.AF_UNSPEC,
+				DstLength: 200,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.m.Validate()
+			if tt.ok && err != nil {
+				t.Fatalf("failed to validate message: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
+
+func TestRouteProtocolString(t *testing.T) {
+	if err := RegisterRouteProtocol(186, "bird"); err != nil {
+		t.Fatalf("failed to register route protocol: %v", err)
+	}
+	defer delete(registeredRouteProtocols, 186)
+
+	if err := RegisterRouteProtocol(186, "bgp"); err == nil {
+		t.Fatal("expected an error registering route protocol twice, but none occurred")
+	}
+
+	tests := []struct {
+		p    RouteProtocol
+		name string
+	}{
+		{p: unix.RTPROT_BOOT, name: "boot"},
+		{p: unix.RTPROT_KERNEL, name: "kernel"},
+		{p: 186, name: "bird"},
+		{p: 250, name: "unknown RouteProtocol value (250)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.p.String(); got != tt.name {
+			t.Errorf("unexpected name for protocol %d, want: %q, got: %q", uint8(tt.p), tt.name, got)
+		}
+	}
+}
+
+func TestRouteFlagsString(t *testing.T) {
+	tests := []struct {
+		f    RouteFlags
+		name string
+	}{
+		{f: 0, name: "0"},
+		{f: RouteFlagNotify, name: "notify"},
+		{f: RouteFlagCloned | RouteFlagOffload, name: "cloned|offload"},
+		{f: RouteFlagTrap | 0x1, name: "trap|0x1"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.f.String(); got != tt.name {
+			t.Errorf("unexpected name for flags %#x, want: %q, got: %q", uint32(tt.f), tt.name, got)
+		}
+	}
+}
+
+func TestRouteMessageIsOffloaded(t *testing.T) {
+	skipBigEndian(t)
+
+	tests := []struct {
+		name  string
+		flags []byte
+		want  RouteFlags
+		ok    bool
+	}{
+		{
+			name:  "not offloaded",
+			flags: []byte{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name:  "offloaded",
+			flags: []byte{0x00, 0x40, 0x00, 0x00},
+			want:  RouteFlagOffload,
+			ok:    true,
+		},
+		{
+			name:  "trapped and offload failed",
+			flags: []byte{0x00, 0x80, 0x00, 0x20},
+			want:  RouteFlagTrap | RouteFlagOffloadFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := append([]byte{
+				0x02, 0x18, 0x00, 0x00, 0xfe, 0x04, 0x00, 0x01,
+			}, tt.flags...)
+
+			var m RouteMessage
+			if err := m.UnmarshalBinary(b); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			if want, got := tt.want, RouteFlags(m.Flags); want != got {
+				t.Fatalf("unexpected flags, want: %s, got: %s", want, got)
+			}
+			if want, got := tt.ok, m.IsOffloaded(); want != got {
+				t.Fatalf("unexpected IsOffloaded, want: %v, got: %v", want, got)
+			}
+		})
+	}
+}
+
+func TestRouteAttributesExpires(t *testing.T) {
+	var a RouteAttributes
+	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if d := a.ExpiresDuration(); d != 0 {
+		t.Fatalf("unexpected duration without Expires: %v", d)
+	}
+	if ts := a.ExpiresFrom(ref); !ts.IsZero() {
+		t.Fatalf("unexpected expiry time without Expires: %v", ts)
+	}
+
+	a.Expires = uint32Ptr(1800)
+	if want, got := 30*time.Minute, a.ExpiresDuration(); want != got {
+		t.Fatalf("unexpected duration, want: %v, got: %v", want, got)
+	}
+	if want, got := ref.Add(30*time.Minute), a.ExpiresFrom(ref); !want.Equal(got) {
+		t.Fatalf("unexpected expiry time, want: %v, got: %v", want, got)
+	}
+}
+
+func TestRouteModifyMerge(t *testing.T) {
+	var (
+		gw    = net.IPv4(192, 0, 2, 1)
+		via   = &RouteVia{Family: unix.AF_INET6, Addr: net.ParseIP("fe80::1")}
+		encap = &RouteEncap{MPLS: []MPLSNextHop{{Label: 100, BottomOfStack: true}}}
+	)
+
+	tests := []struct {
+		name     string
+		existing RouteAttributes
+		req      RouteAttributes
+		want     RouteAttributes
+	}{
+		{
+			name:     "keep via and encap",
+			existing: RouteAttributes{OutIface: 2, Via: via, Encap: encap, Priority: 100},
+			req:      RouteAttributes{Priority: 200},
+			want:     RouteAttributes{OutIface: 2, Via: via, Encap: encap, Priority: 200},
+		},
+		{
+			name:     "via replaces gateway",
+			existing: RouteAttributes{OutIface: 2, Gateway: gw},
+			req:      RouteAttributes{Via: via},
+			want:     RouteAttributes{OutIface: 2, Via: via},
+		},
+		{
+			name:     "gateway replaces via",
+			existing: RouteAttributes{OutIface: 2, Via: via},
+			req:      RouteAttributes{Gateway: gw},
+			want:     RouteAttributes{OutIface: 2, Gateway: gw},
+		},
+		{
+			name:     "encap and ports",
+			existing: RouteAttributes{OutIface: 2},
+			req:      RouteAttributes{Encap: encap, Sport: uint16Ptr(1000), Dport: uint16Ptr(53)},
+			want:     RouteAttributes{OutIface: 2, Encap: encap, Sport: uint16Ptr(1000), Dport: uint16Ptr(53)},
+		},
+		{
+			name:     "nexthop id clears via and encap",
+			existing: RouteAttributes{OutIface: 2, Via: via, Encap: encap},
+			req:      RouteAttributes{NHID: uint32Ptr(1)},
+			want:     RouteAttributes{NHID: uint32Ptr(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mergeRoute(
+				&RouteMessage{Family: unix.AF_INET, Attributes: tt.existing},
+				&RouteMessage{Family: unix.AF_INET, Attributes: tt.req},
+			)
+			if diff := cmp.Diff(tt.want, m.Attributes); diff != "" {
+				t.Fatalf("unexpected merged attributes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -104,6 +104,12 @@ func (c *Conn) Addrs(ifc *net.Interface, family int) (out []*net.IPNet, err erro
 	return
 }
 
+// AddrList returns the IP addresses of all address families configured on
+// the interface. It is equivalent to Addrs(ifc, 0).
+func (c *Conn) AddrList(ifc *net.Interface) ([]*net.IPNet, error) {
+	return c.Addrs(ifc, 0)
+}
+
 // ParseAddr parses a CIDR string into a host address and network mask.
 // This is a convenience wrapper around net.ParseCIDR(), which surprisingly
 // returns the network address and mask instead of the host address and mask.
@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"testing"
 
+	"github.com/jsimonetti/rtnetlink/v2/internal/testutils"
 	"github.com/mdlayher/netlink"
 )
 
@@ -118,3 +119,54 @@ func TestLiveAddrAddDel(t *testing.T) {
 		t.Error("AddrDel: ", err)
 	}
 }
+
+func TestLiveAddrList(t *testing.T) {
+	c, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	lo, err := loopbackInterface(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addrs := []*net.IPNet{
+		MustParseAddr("192.0.2.1/24"),
+		MustParseAddr("2001:db8::1/64"),
+	}
+	for _, a := range addrs {
+		if err := c.AddrAdd(lo, a); err != nil {
+			t.Fatalf("AddrAdd %s: %v", a, err)
+		}
+	}
+
+	got, err := c.AddrList(lo)
+	if err != nil {
+		t.Fatal("AddrList:", err)
+	}
+	for _, a := range addrs {
+		var found bool
+		for _, g := range got {
+			if ipnetEqual(a, g) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("address %s not listed: %v", a, got)
+		}
+	}
+
+	for _, a := range addrs {
+		if err := c.AddrDel(lo, a); err != nil {
+			t.Fatalf("AddrDel %s: %v", a, err)
+		}
+		ok, err := interfaceHasAddr(c, lo, a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			t.Errorf("address %s still present after AddrDel", a)
+		}
+	}
+}
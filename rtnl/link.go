@@ -1,6 +1,7 @@
 package rtnl
 
 import (
+	"fmt"
 	"net"
 
 	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
@@ -30,6 +31,16 @@ func (c *Conn) LinkByIndex(ifindex int) (*net.Interface, error) {
 	return linkmsgToInterface(&rx), nil
 }
 
+// LinkByName returns an interface by its name or one of its alternative
+// names. Similar to net.InterfaceByName.
+func (c *Conn) LinkByName(name string) (*net.Interface, error) {
+	rx, err := c.Conn.Link.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return linkmsgToInterface(rx), nil
+}
+
 func linkmsgToInterface(m *rtnetlink.LinkMessage) *net.Interface {
 	ifc := &net.Interface{
 		Index:        int(m.Index),
@@ -116,3 +127,49 @@ func (c *Conn) LinkDown(ifc *net.Interface) error {
 	}
 	return c.Conn.Link.Set(tx)
 }
+
+// LinkSetMTU sets the MTU of the interface.
+func (c *Conn) LinkSetMTU(ifc *net.Interface, mtu uint32) error {
+	if mtu == 0 {
+		return fmt.Errorf("rtnl: invalid MTU %d", mtu)
+	}
+	tx := &rtnetlink.LinkMessage{
+		Family: unix.AF_UNSPEC,
+		Index:  uint32(ifc.Index),
+		Attributes: &rtnetlink.LinkAttributes{
+			MTU: mtu,
+		},
+	}
+	return c.Conn.Link.Set(tx)
+}
+
+// LinkAdd creates a new interface with the given name, using driver to set
+// the kind and kind specific attributes of the link. Use WithParent to create
+// the link on top of another interface, referenced by its name.
+func (c *Conn) LinkAdd(name string, driver rtnetlink.LinkDriver, options ...LinkOption) error {
+	opts := &LinkOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	tx := &rtnetlink.LinkMessage{
+		Family: unix.AF_UNSPEC,
+		Attributes: &rtnetlink.LinkAttributes{
+			Name: name,
+			Info: &rtnetlink.LinkInfo{
+				Kind: driver.Kind(),
+				Data: driver,
+			},
+		},
+	}
+
+	if opts.Parent != "" {
+		parent, err := c.Conn.Link.GetByName(opts.Parent)
+		if err != nil {
+			return err
+		}
+		tx.Attributes.Type = parent.Index
+	}
+
+	return c.Conn.Link.New(tx)
+}
@@ -0,0 +1,18 @@
+package rtnl
+
+// LinkOptions is the functional options struct
+type LinkOptions struct {
+	Parent string
+}
+
+// LinkOption is the functional options func
+type LinkOption func(*LinkOptions)
+
+// WithParent sets the name of the parent interface a link is created on,
+// such as the lower device of a macvlan or ipvlan link. The name is resolved
+// like LinkByName, so an alternative name can be used as well.
+func WithParent(name string) LinkOption {
+	return func(opts *LinkOptions) {
+		opts.Parent = name
+	}
+}
@@ -8,6 +8,11 @@ import (
 	"net"
 	"strconv"
 	"testing"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/driver"
+	"github.com/jsimonetti/rtnetlink/v2/internal/testutils"
+	"github.com/mdlayher/netlink"
 )
 
 const (
@@ -95,3 +100,123 @@ func TestLiveLinks(t *testing.T) {
 		}
 	})
 }
+
+func TestLiveLinkAddWithParent(t *testing.T) {
+	c, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if err := c.LinkAdd("rtnlp0", &driver.Veth{
+		PeerInfo: &rtnetlink.LinkMessage{
+			Attributes: &rtnetlink.LinkAttributes{
+				Name: "rtnlp1",
+			},
+		},
+	}); err != nil {
+		t.Fatalf("failed to create parent: %v", err)
+	}
+
+	if err := c.LinkAdd("rtnlmv0", &rtnetlink.LinkData{Name: "macvlan"}, WithParent("missing0")); err == nil {
+		t.Fatal("expected an error for a missing parent, but none occurred")
+	}
+
+	if err := c.LinkAdd("rtnlmv0", &rtnetlink.LinkData{Name: "macvlan"}, WithParent("rtnlp0")); err != nil {
+		t.Fatalf("failed to create macvlan: %v", err)
+	}
+
+	parent, err := c.LinkByName("rtnlp0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mv, err := c.LinkByName("rtnlmv0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rx, err := c.Conn.Link.Get(uint32(mv.Index))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, got := "macvlan", rx.Attributes.Info.Kind; want != got {
+		t.Fatalf("unexpected kind, want: %q, got: %q", want, got)
+	}
+	if want, got := uint32(parent.Index), rx.Attributes.Type; want != got {
+		t.Fatalf("unexpected parent index, want: %d, got: %d", want, got)
+	}
+}
+
+func TestLiveLinkUpDown(t *testing.T) {
+	c, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	lo, err := c.LinkByName("lo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lo.Flags&net.FlagLoopback == 0 {
+		t.Fatalf("unexpected interface %#v, expected the loopback interface", lo)
+	}
+
+	up := func() bool {
+		t.Helper()
+		ifc, err := c.LinkByIndex(lo.Index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ifc.Flags&net.FlagUp != 0
+	}
+
+	// The loopback interface of a new network namespace is down.
+	if up() {
+		t.Fatal("expected loopback interface to be down")
+	}
+	if err := c.LinkUp(lo); err != nil {
+		t.Fatal("LinkUp:", err)
+	}
+	if !up() {
+		t.Fatal("expected loopback interface to be up")
+	}
+	if err := c.LinkDown(lo); err != nil {
+		t.Fatal("LinkDown:", err)
+	}
+	if up() {
+		t.Fatal("expected loopback interface to be down")
+	}
+
+	if _, err := c.LinkByName("missing0"); err == nil {
+		t.Fatal("expected an error for a missing interface, but none occurred")
+	}
+}
+
+func TestLiveLinkSetMTU(t *testing.T) {
+	c, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	lo, err := c.LinkByName("lo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.LinkSetMTU(lo, 1500); err != nil {
+		t.Fatal("LinkSetMTU:", err)
+	}
+	ifc, err := c.LinkByIndex(lo.Index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ifc.MTU != 1500 {
+		t.Fatalf("unexpected MTU: want 1500, got %d", ifc.MTU)
+	}
+
+	if err := c.LinkSetMTU(lo, 0); err == nil {
+		t.Fatal("expected an error for a zero MTU, but none occurred")
+	}
+}
@@ -104,8 +104,8 @@ func (c *Conn) RouteDel(ifc *net.Interface, dst net.IPNet) error {
 }
 
 // RouteGet gets a single route to the given destination address.
-func (c *Conn) RouteGet(dst net.IP) (*Route, error) {
-	list, err := c.RouteGetAll(dst)
+func (c *Conn) RouteGet(dst net.IP, options ...RouteOption) (*Route, error) {
+	list, err := c.RouteGetAll(dst, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -118,14 +118,24 @@ func (c *Conn) RouteGet(dst net.IP) (*Route, error) {
 }
 
 // RouteGetAll returns all routes to the given destination IP in the main routing table.
-func (c *Conn) RouteGetAll(dst net.IP) (ret []*Route, err error) {
+//
+// Options such as WithRouteSrc and WithRouteMark are passed on to the route
+// lookup, so that routes selected by policy routing rules are resolved.
+func (c *Conn) RouteGetAll(dst net.IP, options ...RouteOption) (ret []*Route, err error) {
 	af, err := addrFamily(dst)
 	if err != nil {
 		return nil, err
 	}
 
-	attr := rtnetlink.RouteAttributes{
-		Dst: dst,
+	opts := &RouteOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	attr := opts.Attrs
+	attr.Dst = dst
+	if opts.Src != nil {
+		attr.Src = opts.Src.IP
 	}
 
 	tx := &rtnetlink.RouteMessage{
@@ -6,6 +6,12 @@ package rtnl
 import (
 	"net"
 	"testing"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/driver"
+	"github.com/jsimonetti/rtnetlink/v2/internal/testutils"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
 )
 
 func TestLiveRoute(t *testing.T) {
@@ -37,3 +43,79 @@ func TestLiveRoute(t *testing.T) {
 		t.Error("zero route.Interface.HardwareAddr, expected non-zero")
 	}
 }
+
+func TestLiveRouteGetWithMark(t *testing.T) {
+	c, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	const (
+		mark  uint32 = 7
+		table uint32 = 100
+	)
+
+	if err := c.LinkAdd("rtnlm0", &driver.Veth{
+		PeerInfo: &rtnetlink.LinkMessage{
+			Attributes: &rtnetlink.LinkAttributes{
+				Name: "rtnlm1",
+			},
+		},
+	}); err != nil {
+		t.Fatalf("failed to create veth: %v", err)
+	}
+
+	var ifc *net.Interface
+	for _, name := range []string{"rtnlm1", "rtnlm0"} {
+		var err error
+		ifc, err = c.LinkByName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.LinkUp(ifc); err != nil {
+			t.Fatalf("failed to set %s up: %v", name, err)
+		}
+	}
+
+	// only reachable through the table selected by the mark
+	if err := c.Conn.Route.Add(&rtnetlink.RouteMessage{
+		Family:    unix.AF_INET,
+		DstLength: 24,
+		Protocol:  unix.RTPROT_BOOT,
+		Scope:     unix.RT_SCOPE_LINK,
+		Type:      unix.RTN_UNICAST,
+		Attributes: rtnetlink.RouteAttributes{
+			Dst:      net.IPv4(198, 51, 100, 0),
+			OutIface: uint32(ifc.Index),
+			Table:    table,
+		},
+	}); err != nil {
+		t.Fatalf("failed to add route: %v", err)
+	}
+
+	fwmark, tbl := mark, table
+	if err := c.Conn.Rule.Add(&rtnetlink.RuleMessage{
+		Family: unix.AF_INET,
+		Action: unix.FR_ACT_TO_TBL,
+		Attributes: &rtnetlink.RuleAttributes{
+			FwMark: &fwmark,
+			Table:  &tbl,
+		},
+	}); err != nil {
+		t.Fatalf("failed to add rule: %v", err)
+	}
+
+	dst := net.IPv4(198, 51, 100, 1)
+	if _, err := c.RouteGet(dst); err == nil {
+		t.Fatal("expected an error resolving an unmarked route, but none occurred")
+	}
+
+	route, err := c.RouteGet(dst, WithRouteMark(mark))
+	if err != nil {
+		t.Fatalf("failed to resolve marked route: %v", err)
+	}
+	if want, got := ifc.Name, route.Interface.Name; want != got {
+		t.Fatalf("unexpected route interface, want: %q, got: %q", want, got)
+	}
+}
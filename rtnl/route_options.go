@@ -45,3 +45,12 @@ func WithRouteAttrs(attrs rtnetlink.RouteAttributes) RouteOption {
 		opts.Attrs = attrs
 	}
 }
+
+// WithRouteMark sets the firewall mark (RTA_MARK). When used with RouteGet
+// and RouteGetAll, the route lookup takes policy routing rules matching the
+// mark into account.
+func WithRouteMark(mark uint32) RouteOption {
+	return func(opts *RouteOptions) {
+		opts.Attrs.Mark = mark
+	}
+}
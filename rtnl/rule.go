@@ -0,0 +1,33 @@
+package rtnl
+
+import (
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+
+	"github.com/jsimonetti/rtnetlink/v2"
+)
+
+// RuleAdd adds a policy routing rule of the address family that looks up
+// routes in the table, with the given priority.
+//
+//	conn.RuleAdd(unix.AF_INET, 100, 1000, rtnl.WithRuleFwMark(7, 0))
+func (c *Conn) RuleAdd(family int, table uint32, priority uint32, options ...RuleOption) error {
+	opts := &RuleOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	opts.Attrs.Table = &table
+	opts.Attrs.Priority = &priority
+
+	tx := &rtnetlink.RuleMessage{
+		Family:     uint8(family),
+		Action:     unix.FR_ACT_TO_TBL,
+		Attributes: &opts.Attrs,
+	}
+	// Tables above 255 only fit in the FRA_TABLE attribute.
+	if table < 256 {
+		tx.Table = uint8(table)
+	}
+
+	return c.Conn.Rule.Add(tx)
+}
@@ -0,0 +1,68 @@
+//go:build integration
+// +build integration
+
+package rtnl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jsimonetti/rtnetlink/v2"
+	"github.com/jsimonetti/rtnetlink/v2/internal/testutils"
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
+	"github.com/mdlayher/netlink"
+)
+
+func TestLiveRuleAdd(t *testing.T) {
+	c, err := Dial(&netlink.Config{NetNS: testutils.NetNS(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	const (
+		table    uint32 = 1000
+		priority uint32 = 100
+	)
+
+	if err := c.RuleAdd(unix.AF_INET, table, priority,
+		WithRuleFwMark(7, 0xff),
+		WithRuleIIFName("lo"),
+		WithRuleSuppressPrefixLen(0),
+		WithRuleUIDRange(1000, 2000),
+	); err != nil {
+		t.Fatal("RuleAdd:", err)
+	}
+
+	rules, err := c.Conn.Rule.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range rules {
+		if r.Family != unix.AF_INET || r.Attributes == nil ||
+			r.Attributes.Priority == nil || *r.Attributes.Priority != priority {
+			continue
+		}
+
+		a := r.Attributes
+		if a.Table == nil || *a.Table != table {
+			t.Fatalf("unexpected table: %v", a.Table)
+		}
+		if a.FwMark == nil || *a.FwMark != 7 || a.FwMask == nil || *a.FwMask != 0xff {
+			t.Fatalf("unexpected firewall mark: %v/%v", a.FwMark, a.FwMask)
+		}
+		if a.IIFName == nil || *a.IIFName != "lo" {
+			t.Fatalf("unexpected input interface: %v", a.IIFName)
+		}
+		if a.SuppressPrefixLen == nil || *a.SuppressPrefixLen != 0 {
+			t.Fatalf("unexpected suppressed prefix length: %v", a.SuppressPrefixLen)
+		}
+		if diff := cmp.Diff(&rtnetlink.RuleUIDRange{Start: 1000, End: 2000}, a.UIDRange); diff != "" {
+			t.Fatalf("unexpected UID range (-want +got):\n%s", diff)
+		}
+		return
+	}
+
+	t.Fatalf("rule with priority %d not found", priority)
+}
@@ -0,0 +1,54 @@
+package rtnl
+
+import (
+	"github.com/jsimonetti/rtnetlink/v2"
+)
+
+// RuleOptions is the functional options struct
+type RuleOptions struct {
+	Attrs rtnetlink.RuleAttributes
+}
+
+// RuleOption is the functional options func
+type RuleOption func(*RuleOptions)
+
+// WithRuleFwMark matches packets with the firewall mark. A mask of 0 keeps
+// the kernel default of matching all bits of the mark.
+func WithRuleFwMark(mark, mask uint32) RuleOption {
+	return func(opts *RuleOptions) {
+		opts.Attrs.FwMark = &mark
+		if mask != 0 {
+			opts.Attrs.FwMask = &mask
+		}
+	}
+}
+
+// WithRuleIIFName matches packets received on the interface with the name.
+func WithRuleIIFName(name string) RuleOption {
+	return func(opts *RuleOptions) {
+		opts.Attrs.IIFName = &name
+	}
+}
+
+// WithRuleOIFName matches packets sent on the interface with the name.
+func WithRuleOIFName(name string) RuleOption {
+	return func(opts *RuleOptions) {
+		opts.Attrs.OIFName = &name
+	}
+}
+
+// WithRuleSuppressPrefixLen rejects routing decisions of the rule that have a
+// prefix length of length or less.
+func WithRuleSuppressPrefixLen(length uint32) RuleOption {
+	return func(opts *RuleOptions) {
+		opts.Attrs.SuppressPrefixLen = &length
+	}
+}
+
+// WithRuleUIDRange matches packets of sockets owned by the UIDs from start
+// through end.
+func WithRuleUIDRange(start, end uint32) RuleOption {
+	return func(opts *RuleOptions) {
+		opts.Attrs.UIDRange = &rtnetlink.RuleUIDRange{Start: start, End: end}
+	}
+}
@@ -109,6 +109,33 @@ func (m *RuleMessage) UnmarshalBinary(b []byte) error {
 // rtMessage is an empty method to sattisfy the Message interface.
 func (*RuleMessage) rtMessage() {}
 
+// Validate verifies that the prefix lengths and the source and destination
+// addresses of an IPv4 or IPv6 RuleMessage match its address family.
+func (m *RuleMessage) Validate() error {
+	if err := validatePrefix(m.Family, m.DstLength); err != nil {
+		return err
+	}
+
+	if err := validatePrefix(m.Family, m.SrcLength); err != nil {
+		return err
+	}
+
+	if m.Attributes == nil {
+		return nil
+	}
+
+	for _, ip := range []*net.IP{m.Attributes.Src, m.Attributes.Dst} {
+		if ip == nil {
+			continue
+		}
+		if err := validateIP(m.Family, *ip); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // RuleService is used to retrieve rtnetlink family information.
 type RuleService struct {
 	c *Conn
@@ -127,6 +154,10 @@ func (r *RuleService) execute(m Message, family uint16, flags netlink.HeaderFlag
 
 // Add new rule
 func (r *RuleService) Add(req *RuleMessage) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
 	flags := netlink.Request | netlink.Create | netlink.Acknowledge | netlink.Excl
 	_, err := r.c.Execute(req, unix.RTM_NEWRULE, flags)
 
@@ -135,6 +166,10 @@ func (r *RuleService) Add(req *RuleMessage) error {
 
 // Replace or add new rule
 func (r *RuleService) Replace(req *RuleMessage) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
 	flags := netlink.Request | netlink.Create | netlink.Replace | netlink.Acknowledge
 	_, err := r.c.Execute(req, unix.RTM_NEWRULE, flags)
 
@@ -377,9 +412,10 @@ func marshalRulePortRange(s RulePortRange) ([]byte, error) {
 	return buf.Bytes(), err
 }
 
-// RuleUIDRange defines the start and end for UID matches
+// RuleUIDRange defines the start and end for UID matches, the struct
+// fib_rule_uid_range of the kernel
 type RuleUIDRange struct {
-	Start, End uint16
+	Start, End uint32
 }
 
 func (r *RuleUIDRange) unmarshalBinary(data []byte) error {
@@ -6,6 +6,8 @@ import (
 	"net"
 	"reflect"
 	"testing"
+
+	"github.com/jsimonetti/rtnetlink/v2/internal/unix"
 )
 
 func TestRuleMessage(t *testing.T) {
@@ -85,9 +87,9 @@ func TestRuleMessage(t *testing.T) {
 				0x0b, 0x00, 0x0b, 0x00, 0x0d, 0x00, 0x0c, 0x00, 0x0c, 0x00, 0x11, 0x00, 0x00, 0x00,
 				0x00, 0x00, 0x00, 0x00, 0x05, 0x00, 0x13, 0x00, 0x07, 0x00, 0x00, 0x00, 0x05, 0x00,
 				0x16, 0x00, 0x17, 0x00, 0x00, 0x00, 0x08, 0x00, 0x0d, 0x00, 0x25, 0x00, 0x00, 0x00,
-				0x08, 0x00, 0x0e, 0x00, 0x1f, 0x00, 0x00, 0x00, 0x08, 0x00, 0x14, 0x00, 0x16, 0x00,
-				0x19, 0x00, 0x08, 0x00, 0x17, 0x00, 0x17, 0x00, 0x1a, 0x00, 0x08, 0x00, 0x18, 0x00,
-				0x18, 0x00, 0x1b, 0x00,
+				0x08, 0x00, 0x0e, 0x00, 0x1f, 0x00, 0x00, 0x00, 0x0c, 0x00, 0x14, 0x00, 0x16, 0x00,
+				0x00, 0x00, 0x19, 0x00, 0x00, 0x00, 0x08, 0x00, 0x17, 0x00, 0x17, 0x00, 0x1a, 0x00,
+				0x08, 0x00, 0x18, 0x00, 0x18, 0x00, 0x1b, 0x00,
 			},
 		},
 	}
@@ -198,3 +200,53 @@ func netIPPtr(v net.IP) *net.IP {
 func strPtr(v string) *string {
 	return &v
 }
+
+func TestRuleMessageValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *RuleMessage
+		ok   bool
+	}{
+		{
+			name: "IPv4 source prefix too long",
+			m: &RuleMessage{
+				Family:    unix.AF_INET,
+				SrcLength: 33,
+			},
+		},
+		{
+			name: "IPv4 destination with IPv6 family",
+			m: &RuleMessage{
+				Family:    unix.AF_INET6,
+				DstLength: 24,
+				Attributes: &RuleAttributes{
+					Dst: netIPPtr(net.IPv4(192, 0, 2, 0)),
+				},
+			},
+		},
+		{
+			name: "OK",
+			m: &RuleMessage{
+				Family:    unix.AF_INET,
+				SrcLength: 24,
+				Attributes: &RuleAttributes{
+					Src:   netIPPtr(net.IPv4(192, 0, 2, 0)),
+					Table: uint32Ptr(100),
+				},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.m.Validate()
+			if tt.ok && err != nil {
+				t.Fatalf("failed to validate message: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}